@@ -5,6 +5,7 @@ package mocks
 import mock "github.com/stretchr/testify/mock"
 import sdk "github.com/proximax-storage/go-xpx-chain-sdk/sdk"
 import subscribers "github.com/proximax-storage/go-xpx-chain-sdk/sdk/websocket/subscribers"
+import websocket "github.com/proximax-storage/go-xpx-chain-sdk/sdk/websocket"
 
 // CatapultClient is an autogenerated mock type for the CatapultClient type
 type CatapultClient struct {
@@ -94,6 +95,27 @@ func (_m *CatapultClient) AddDriveStateHandlers(address *sdk.Address, handlers .
 	return r0
 }
 
+// AddDriveDiffHandlers provides a mock function with given fields: drive, fetcher, handlers
+func (_m *CatapultClient) AddDriveDiffHandlers(drive *sdk.PublicAccount, fetcher websocket.DriveFetcher, handlers ...subscribers.DriveDiffHandler) error {
+	_va := make([]interface{}, len(handlers))
+	for _i := range handlers {
+		_va[_i] = handlers[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, drive, fetcher)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*sdk.PublicAccount, websocket.DriveFetcher, ...subscribers.DriveDiffHandler) error); ok {
+		r0 = rf(drive, fetcher, handlers...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // AddPartialAddedHandlers provides a mock function with given fields: address, handlers
 func (_m *CatapultClient) AddPartialAddedHandlers(address *sdk.Address, handlers ...subscribers.PartialAddedHandler) error {
 	_va := make([]interface{}, len(handlers))
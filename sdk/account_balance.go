@@ -0,0 +1,535 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"context"
+	"strings"
+)
+
+// GetBalanceAtHeight returns balance of mosaic on address after block with passed height.
+// Balance is reconstructed by replaying confirmed transactions of address from nemesis block,
+// i.e. transfers, levies, hash and secret locks, exchange offers, storage deposits and fees.
+// Changes which are not caused by transactions, like harvesting rewards, expired locks and offers
+// or payouts of drives, are visible only in receipts and are not replayed.
+// Namespace is resolved to mosaic by its current alias and levies are charged by current levy of mosaic,
+// use GetAccountHistory to check whether balance is estimated
+func (a *AccountService) GetBalanceAtHeight(ctx context.Context, address *Address, mosaic AssetId, height Height) (Amount, error) {
+	if address == nil {
+		return 0, ErrNilAddress
+	}
+
+	if mosaic == nil {
+		return 0, ErrNilAssetId
+	}
+
+	if height == 0 {
+		return 0, ErrArgumentNotValid
+	}
+
+	mosaicId, err := newBalanceChangeDecoder(a.client, address).mosaicId(ctx, mosaic)
+	if err != nil {
+		return 0, err
+	}
+
+	history, err := a.GetAccountHistory(ctx, address, &AccountHistoryOptions{ToHeight: height})
+	if err != nil {
+		return 0, err
+	}
+
+	return history.Balance(mosaicId), nil
+}
+
+type secretLockDeposit struct {
+	Mosaic    *Mosaic
+	Signer    *Address
+	Recipient *Address
+}
+
+type offerDepositKey struct {
+	Owner    string
+	Type     OfferType
+	MosaicId uint64
+}
+
+// resolveMosaic returns mosaic with asset resolved to MosaicId
+func (d *balanceChangeDecoder) resolveMosaic(ctx context.Context, mosaic *Mosaic) (*Mosaic, error) {
+	if mosaic == nil {
+		return nil, ErrNilMosaic
+	}
+
+	mosaicId, err := d.mosaicId(ctx, mosaic.AssetId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Mosaic{AssetId: mosaicId, Amount: mosaic.Amount}, nil
+}
+
+// drive returns drive by its account, drives are cached because their sizes don't change in history
+func (d *balanceChangeDecoder) drive(ctx context.Context, driveKey *PublicAccount) (*Drive, error) {
+	if driveKey == nil {
+		return nil, ErrNilAccount
+	}
+
+	if drive, ok := d.drives[driveKey.PublicKey]; ok {
+		return drive, nil
+	}
+
+	drive, err := d.client.Storage.GetDrive(ctx, driveKey)
+	if err != nil {
+		return nil, err
+	}
+
+	d.drives[driveKey.PublicKey] = drive
+	return drive, nil
+}
+
+func (d *balanceChangeDecoder) decodeHashLock(ctx context.Context, tx *LockFundsTransaction) ([]*BalanceChange, error) {
+	if !d.isSigner(tx) || tx.SignedTransaction == nil {
+		return nil, nil
+	}
+
+	mosaic, err := d.resolveMosaic(ctx, tx.Mosaic)
+	if err != nil {
+		return nil, err
+	}
+
+	d.hashLocks[*tx.SignedTransaction.Hash] = mosaic
+
+	return []*BalanceChange{{
+		Type:        LockBalanceChange,
+		MosaicId:    mosaic.AssetId.(*MosaicId),
+		Amount:      -mosaic.Amount,
+		Transaction: tx,
+	}}, nil
+}
+
+// decodeHashLockRelease returns funds locked by account when locked aggregate bonded transaction is confirmed
+func (d *balanceChangeDecoder) decodeHashLockRelease(tx *AggregateTransaction) []*BalanceChange {
+	if tx.TransactionHash == nil {
+		return nil
+	}
+
+	mosaic, ok := d.hashLocks[*tx.TransactionHash]
+	if !ok {
+		return nil
+	}
+
+	delete(d.hashLocks, *tx.TransactionHash)
+
+	return []*BalanceChange{{
+		Type:        LockBalanceChange,
+		MosaicId:    mosaic.AssetId.(*MosaicId),
+		Amount:      mosaic.Amount,
+		Transaction: tx,
+	}}
+}
+
+func secretLockKey(secret *Secret, recipient *Address) string {
+	return secret.HashString() + recipient.Address
+}
+
+func (d *balanceChangeDecoder) decodeSecretLock(ctx context.Context, tx *SecretLockTransaction) ([]*BalanceChange, error) {
+	if tx.Secret == nil {
+		return nil, ErrNilSecret
+	}
+
+	recipient, err := d.resolveAddress(ctx, tx.Recipient)
+	if err != nil {
+		return nil, err
+	}
+
+	mosaic, err := d.resolveMosaic(ctx, tx.Mosaic)
+	if err != nil {
+		return nil, err
+	}
+
+	// lock is tracked for recipient too, because it is credited by proof of other account
+	d.secretLocks[secretLockKey(tx.Secret, recipient)] = &secretLockDeposit{
+		Mosaic:    mosaic,
+		Signer:    tx.Signer.Address,
+		Recipient: recipient,
+	}
+
+	if !d.isSigner(tx) {
+		return nil, nil
+	}
+
+	return []*BalanceChange{{
+		Type:         SecretLockBalanceChange,
+		MosaicId:     mosaic.AssetId.(*MosaicId),
+		Amount:       -mosaic.Amount,
+		Counterparty: recipient,
+		Transaction:  tx,
+	}}, nil
+}
+
+func (d *balanceChangeDecoder) decodeSecretProof(ctx context.Context, tx *SecretProofTransaction) ([]*BalanceChange, error) {
+	if tx.Proof == nil {
+		return nil, ErrNilProof
+	}
+
+	recipient, err := d.resolveAddress(ctx, tx.Recipient)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := tx.Proof.Secret(tx.HashType)
+	if err != nil {
+		return nil, err
+	}
+
+	key := secretLockKey(secret, recipient)
+	lock, ok := d.secretLocks[key]
+	if !ok {
+		return nil, nil
+	}
+
+	delete(d.secretLocks, key)
+
+	if !d.isOwn(lock.Recipient) {
+		return nil, nil
+	}
+
+	return []*BalanceChange{{
+		Type:         SecretLockBalanceChange,
+		MosaicId:     lock.Mosaic.AssetId.(*MosaicId),
+		Amount:       lock.Mosaic.Amount,
+		Counterparty: lock.Signer,
+		Transaction:  tx,
+	}}, nil
+}
+
+func (d *balanceChangeDecoder) newExchangeChange(mosaicId *MosaicId, amount Amount, counterparty *PublicAccount, tx Transaction) *BalanceChange {
+	change := &BalanceChange{
+		Type:        ExchangeBalanceChange,
+		MosaicId:    mosaicId,
+		Amount:      amount,
+		Transaction: tx,
+	}
+
+	if counterparty != nil {
+		change.Counterparty = counterparty.Address
+	}
+
+	return change
+}
+
+// decodeAddExchangeOffer debits account with deposit of offers,
+// mosaics are deposited for sell offers and cost in XPX is deposited for buy offers
+func (d *balanceChangeDecoder) decodeAddExchangeOffer(ctx context.Context, tx *AddExchangeOfferTransaction) ([]*BalanceChange, error) {
+	if !d.isSigner(tx) {
+		return nil, nil
+	}
+
+	xpxId, err := d.mosaicId(ctx, XpxNamespaceId)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]*BalanceChange, 0, len(tx.Offers))
+	for _, o := range tx.Offers {
+		mosaic, err := d.resolveMosaic(ctx, o.Mosaic)
+		if err != nil {
+			return nil, err
+		}
+
+		deposit := &Mosaic{AssetId: mosaic.AssetId, Amount: mosaic.Amount}
+		if o.Type == BuyOffer {
+			deposit = &Mosaic{AssetId: xpxId, Amount: o.Cost}
+		}
+
+		key := offerDepositKey{tx.Signer.PublicKey, o.Type, mosaic.AssetId.Id()}
+		if tracked, ok := d.offers[key]; ok {
+			tracked.Amount += deposit.Amount
+		} else {
+			d.offers[key] = deposit
+		}
+
+		changes = append(changes, d.newExchangeChange(deposit.AssetId.(*MosaicId), -deposit.Amount, nil, tx))
+	}
+
+	return changes, nil
+}
+
+// decodeExchangeOffer returns changes of account which confirms offers or owns confirmed offers.
+// Owner of offer is paid from deposit, so only the counter asset is credited to owner
+func (d *balanceChangeDecoder) decodeExchangeOffer(ctx context.Context, tx *ExchangeOfferTransaction) ([]*BalanceChange, error) {
+	xpxId, err := d.mosaicId(ctx, XpxNamespaceId)
+	if err != nil {
+		return nil, err
+	}
+
+	isSigner := d.isSigner(tx)
+	changes := make([]*BalanceChange, 0)
+
+	for _, c := range tx.Confirmations {
+		if c.Owner == nil {
+			return nil, ErrNilAccount
+		}
+
+		mosaic, err := d.resolveMosaic(ctx, c.Mosaic)
+		if err != nil {
+			return nil, err
+		}
+
+		mosaicId := mosaic.AssetId.(*MosaicId)
+		isOwner := d.isOwn(c.Owner.Address)
+
+		if isSigner {
+			switch c.Type {
+			case SellOffer:
+				changes = append(changes,
+					d.newExchangeChange(xpxId, -c.Cost, c.Owner, tx),
+					d.newExchangeChange(mosaicId, mosaic.Amount, c.Owner, tx))
+			case BuyOffer:
+				changes = append(changes,
+					d.newExchangeChange(mosaicId, -mosaic.Amount, c.Owner, tx),
+					d.newExchangeChange(xpxId, c.Cost, c.Owner, tx))
+			}
+		}
+
+		deposit := d.offers[offerDepositKey{c.Owner.PublicKey, c.Type, mosaicId.Id()}]
+
+		switch c.Type {
+		case SellOffer:
+			if isOwner {
+				changes = append(changes, d.newExchangeChange(xpxId, c.Cost, tx.Signer, tx))
+			}
+			if deposit != nil {
+				deposit.Amount -= mosaic.Amount
+			}
+		case BuyOffer:
+			if isOwner {
+				changes = append(changes, d.newExchangeChange(mosaicId, mosaic.Amount, tx.Signer, tx))
+			}
+			if deposit != nil {
+				deposit.Amount -= c.Cost
+			}
+		}
+	}
+
+	return changes, nil
+}
+
+// decodeRemoveExchangeOffer returns remaining deposit of removed offers to account
+func (d *balanceChangeDecoder) decodeRemoveExchangeOffer(ctx context.Context, tx *RemoveExchangeOfferTransaction) ([]*BalanceChange, error) {
+	if !d.isSigner(tx) {
+		return nil, nil
+	}
+
+	changes := make([]*BalanceChange, 0, len(tx.Offers))
+	for _, o := range tx.Offers {
+		mosaicId, err := d.mosaicId(ctx, o.AssetId)
+		if err != nil {
+			return nil, err
+		}
+
+		key := offerDepositKey{tx.Signer.PublicKey, o.Type, mosaicId.Id()}
+		deposit, ok := d.offers[key]
+		if !ok {
+			continue
+		}
+
+		delete(d.offers, key)
+
+		if deposit.Amount > 0 {
+			changes = append(changes, d.newExchangeChange(deposit.AssetId.(*MosaicId), deposit.Amount, nil, tx))
+		}
+	}
+
+	return changes, nil
+}
+
+// decodeJoinToDrive debits replicator with deposit of storage units equal to size of drive
+func (d *balanceChangeDecoder) decodeJoinToDrive(ctx context.Context, tx *JoinToDriveTransaction) ([]*BalanceChange, error) {
+	if !d.isSigner(tx) {
+		return nil, nil
+	}
+
+	drive, err := d.drive(ctx, tx.DriveKey)
+	if err != nil {
+		return nil, err
+	}
+
+	storageId, err := d.mosaicId(ctx, StorageNamespaceId)
+	if err != nil {
+		return nil, err
+	}
+
+	d.driveDeposits[tx.DriveKey.PublicKey] = &Mosaic{AssetId: storageId, Amount: Amount(drive.DriveSize)}
+
+	return []*BalanceChange{{
+		Type:         StorageDepositBalanceChange,
+		MosaicId:     storageId,
+		Amount:       -Amount(drive.DriveSize),
+		Counterparty: tx.DriveKey.Address,
+		Transaction:  tx,
+	}}, nil
+}
+
+// fileSize returns size of file recorded by file system transaction of drive which added it up to height.
+// Files can be removed from drive later, so their sizes are taken from history of drive instead of its current state
+func (d *balanceChangeDecoder) fileSize(ctx context.Context, driveKey *PublicAccount, fileHash *Hash, height Height) (StorageSize, error) {
+	if driveKey == nil {
+		return 0, ErrNilAccount
+	}
+
+	sizes, ok := d.fileSizes[driveKey.PublicKey]
+	if !ok {
+		sizes = make(map[Hash]StorageSize)
+		d.fileSizes[driveKey.PublicKey] = sizes
+	}
+
+	if from := d.fileSizesTo[driveKey.PublicKey]; from < height {
+		if err := d.loadFileSizes(ctx, driveKey, sizes, from+1, height); err != nil {
+			return 0, err
+		}
+
+		d.fileSizesTo[driveKey.PublicKey] = height
+	}
+
+	return sizes[*fileHash], nil
+}
+
+// loadFileSizes collects sizes of files added to drive by confirmed file system transactions within heights
+func (d *balanceChangeDecoder) loadFileSizes(ctx context.Context, driveKey *PublicAccount, sizes map[Hash]StorageSize, from, to Height) error {
+	tpOpts := &TransactionsPageOptions{
+		FromHeight: uint64(from),
+		ToHeight:   uint64(to),
+		Address:    driveKey.Address.Address,
+		Type:       []uint{uint(DriveFileSystem)},
+		Embedded:   true,
+		PaginationOrderingOptions: PaginationOrderingOptions{
+			PageSize:      defaultAccountHistoryPageSize,
+			SortField:     "meta.height",
+			SortDirection: ASC.String(),
+		},
+	}
+
+	var collect func(tx Transaction)
+	collect = func(tx Transaction) {
+		switch tx := tx.(type) {
+		case *DriveFileSystemTransaction:
+			if !strings.EqualFold(tx.DriveKey, driveKey.PublicKey) {
+				return
+			}
+
+			for _, a := range tx.AddActions {
+				if a.FileHash != nil {
+					sizes[*a.FileHash] = a.FileSize
+				}
+			}
+		case *AggregateTransaction:
+			for _, inner := range tx.InnerTransactions {
+				collect(inner)
+			}
+		}
+	}
+
+	it := d.client.Transaction.IterateTransactionsByGroup(ctx, Confirmed, tpOpts, nil)
+	defer it.Close()
+
+	for it.Next() {
+		collect(it.Transaction())
+	}
+
+	return it.Err()
+}
+
+// decodeFilesDeposit debits replicator with deposit of streaming units equal to size of files
+func (d *balanceChangeDecoder) decodeFilesDeposit(ctx context.Context, tx *FilesDepositTransaction) ([]*BalanceChange, error) {
+	if !d.isSigner(tx) {
+		return nil, nil
+	}
+
+	var size Amount
+	for _, f := range tx.Files {
+		if f.FileHash == nil {
+			continue
+		}
+
+		fileSize, err := d.fileSize(ctx, tx.DriveKey, f.FileHash, tx.Height)
+		if err != nil {
+			return nil, err
+		}
+
+		size += Amount(fileSize)
+	}
+
+	if size == 0 {
+		return nil, nil
+	}
+
+	streamingId, err := d.mosaicId(ctx, StreamingNamespaceId)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*BalanceChange{{
+		Type:         StorageDepositBalanceChange,
+		MosaicId:     streamingId,
+		Amount:       -size,
+		Counterparty: tx.DriveKey.Address,
+		Transaction:  tx,
+	}}, nil
+}
+
+// decodeEndDrive returns deposit of drive to replicator
+func (d *balanceChangeDecoder) decodeEndDrive(ctx context.Context, tx *EndDriveTransaction) ([]*BalanceChange, error) {
+	if tx.DriveKey == nil {
+		return nil, ErrNilAccount
+	}
+
+	deposit, ok := d.driveDeposits[tx.DriveKey.PublicKey]
+	if !ok {
+		return nil, nil
+	}
+
+	delete(d.driveDeposits, tx.DriveKey.PublicKey)
+
+	return []*BalanceChange{{
+		Type:         StorageDepositBalanceChange,
+		MosaicId:     deposit.AssetId.(*MosaicId),
+		Amount:       deposit.Amount,
+		Counterparty: tx.DriveKey.Address,
+		Transaction:  tx,
+	}}, nil
+}
+
+// decodeStartFileDownload debits recipient of files with streaming units equal to size of files
+func (d *balanceChangeDecoder) decodeStartFileDownload(ctx context.Context, tx *StartFileDownloadTransaction) ([]*BalanceChange, error) {
+	if !d.isSigner(tx) {
+		return nil, nil
+	}
+
+	var size Amount
+	for _, f := range tx.Files {
+		size += Amount(f.FileSize)
+	}
+
+	if size == 0 {
+		return nil, nil
+	}
+
+	streamingId, err := d.mosaicId(ctx, StreamingNamespaceId)
+	if err != nil {
+		return nil, err
+	}
+
+	change := &BalanceChange{
+		Type:        DownloadBalanceChange,
+		MosaicId:    streamingId,
+		Amount:      -size,
+		Transaction: tx,
+	}
+
+	if tx.Drive != nil {
+		change.Counterparty = tx.Drive.Address
+	}
+
+	return []*BalanceChange{change}, nil
+}
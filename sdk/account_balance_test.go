@@ -0,0 +1,229 @@
+package sdk
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testBalanceStorageId = newMosaicIdPanic(0x6C5D687508AC9D75)
+	testBalanceDrive, _  = NewAccountFromPublicKey("415C7C61822B063F62A4876A6F6BA2DAAE114AB298D7AC7FC56FDBA95872C309", PublicTest)
+	testBalanceLockHash  = &Hash{1}
+	testBalanceProof     = NewProofFromString("proof")
+)
+
+func newTestBalanceTx(height Height, signer *PublicAccount) AbstractTransaction {
+	return AbstractTransaction{
+		TransactionInfo: TransactionInfo{Height: height},
+		MaxFee:          Amount(10),
+		Signer:          signer,
+	}
+}
+
+// testBalanceCorpus returns transactions of testHistoryAccount and its balances after them.
+// Replay is compared with account info of node by integration tests, so balances here only guard replay against regressions
+func testBalanceCorpus(t *testing.T) ([]Transaction, map[MosaicId]Amount) {
+	secret, err := testBalanceProof.Secret(SHA3_256)
+	assert.Nil(t, err)
+
+	otherSecret, err := NewProofFromString("other").Secret(SHA3_256)
+	assert.Nil(t, err)
+
+	bonded := &AggregateTransaction{
+		AbstractTransaction: newTestBalanceTx(3, testHistoryAccount),
+		InnerTransactions: []Transaction{
+			newTestHistoryTransfer(3, testHistoryAccount, testHistoryCounterparty.Address, newMosaicPanic(testHistoryMosaicId, 1000)),
+		},
+	}
+	bonded.Type = AggregateBonded
+	bonded.TransactionHash = testBalanceLockHash
+
+	txs := []Transaction{
+		newTestHistoryTransfer(1, testHistoryCounterparty, testHistoryAccount.Address,
+			newMosaicPanic(XpxNamespaceId, 100000), newMosaicPanic(testHistoryMosaicId, 5000), newMosaicPanic(StorageNamespaceId, 5000)),
+		&LockFundsTransaction{
+			AbstractTransaction: newTestBalanceTx(2, testHistoryAccount),
+			Mosaic:              newMosaicPanic(XpxNamespaceId, 10000),
+			Duration:            100,
+			SignedTransaction:   &SignedTransaction{EntityType: AggregateBonded, Hash: testBalanceLockHash},
+		},
+		bonded,
+		&SecretLockTransaction{
+			AbstractTransaction: newTestBalanceTx(4, testHistoryAccount),
+			Mosaic:              newMosaicPanic(XpxNamespaceId, 2000),
+			Duration:            100,
+			Secret:              otherSecret,
+			Recipient:           testHistoryCounterparty.Address,
+		},
+		&SecretLockTransaction{
+			AbstractTransaction: newTestBalanceTx(4, testHistoryCounterparty),
+			Mosaic:              newMosaicPanic(testHistoryMosaicId, 300),
+			Duration:            100,
+			Secret:              secret,
+			Recipient:           testHistoryAccount.Address,
+		},
+		&SecretProofTransaction{
+			AbstractTransaction: newTestBalanceTx(5, testHistoryCounterparty),
+			HashType:            SHA3_256,
+			Proof:               testBalanceProof,
+			Recipient:           testHistoryAccount.Address,
+		},
+		&AddExchangeOfferTransaction{
+			AbstractTransaction: newTestBalanceTx(6, testHistoryAccount),
+			Offers: []*AddOffer{
+				{Offer{SellOffer, newMosaicPanic(testHistoryMosaicId, 1000), 500}, 100},
+				{Offer{BuyOffer, newMosaicPanic(testHistoryMosaicId, 200), 100}, 100},
+			},
+		},
+		&ExchangeOfferTransaction{
+			AbstractTransaction: newTestBalanceTx(7, testHistoryCounterparty),
+			Confirmations: []*ExchangeConfirmation{
+				{Offer{SellOffer, newMosaicPanic(testHistoryMosaicId, 400), 200}, testHistoryAccount},
+			},
+		},
+		&ExchangeOfferTransaction{
+			AbstractTransaction: newTestBalanceTx(8, testHistoryAccount),
+			Confirmations: []*ExchangeConfirmation{
+				{Offer{SellOffer, newMosaicPanic(testHistoryMosaicId, 50), 25}, testHistoryCounterparty},
+			},
+		},
+		&RemoveExchangeOfferTransaction{
+			AbstractTransaction: newTestBalanceTx(9, testHistoryAccount),
+			Offers:              []*RemoveOffer{{SellOffer, testHistoryMosaicId}},
+		},
+		&JoinToDriveTransaction{
+			AbstractTransaction: newTestBalanceTx(10, testHistoryAccount),
+			DriveKey:            testBalanceDrive,
+		},
+	}
+
+	// fee is limited by max fee of 10 in 7 signed transactions, buy offer still holds deposit of 100 xpx
+	balances := map[MosaicId]Amount{
+		*testHistoryXpxId:     97990,
+		*testHistoryMosaicId:  3950,
+		*testBalanceStorageId: 4000,
+	}
+
+	return txs, balances
+}
+
+func newTestBalanceDecoder() *balanceChangeDecoder {
+	d := newTestBalanceChangeDecoder()
+	d.mosaicIds[StorageNamespaceId.Id()] = testBalanceStorageId
+	d.levies[*testBalanceStorageId] = nil
+	d.drives[testBalanceDrive.PublicKey] = &Drive{DriveAccount: testBalanceDrive, DriveSize: 1000}
+	for h := Height(1); h <= 10; h++ {
+		d.feeMultipliers[h] = 1
+	}
+	return d
+}
+
+func TestAccountHistory_ReplayCorpus(t *testing.T) {
+	txs, balances := testBalanceCorpus(t)
+	d := newTestBalanceDecoder()
+
+	entries := make([]*AccountHistoryEntry, 0, len(txs))
+	for _, tx := range txs {
+		changes, err := d.decode(ctx, tx)
+		assert.Nil(t, err)
+		entries = append(entries, &AccountHistoryEntry{Height: tx.GetAbstractTransaction().Height, Transaction: tx, Changes: changes})
+	}
+
+	history := newAccountHistory(testHistoryAccount.Address, entries, nil)
+	assert.Equal(t, balances, history.Balances)
+
+	// lock is returned when bonded aggregate is confirmed
+	lockChanges := make([]Amount, 0)
+	for _, c := range history.Changes(testHistoryXpxId) {
+		if c.Type == LockBalanceChange {
+			lockChanges = append(lockChanges, c.Amount)
+		}
+	}
+	assert.Equal(t, []Amount{-10000, 10000}, lockChanges)
+	assert.Empty(t, d.hashLocks)
+	// only the lock without proof is left
+	assert.Len(t, d.secretLocks, 1)
+}
+
+func testBalanceFileSystemJson(height int, fileHash *Hash, fileSize int) string {
+	return fmt.Sprintf(`{
+		"meta": {"height": [%d, 0], "hash": "%064X", "merkleComponentHash": "%064X", "index": 0, "id": "5B686E97F0C0EA00017B9437"},
+		"transaction": {
+			"signature": "%0128X",
+			"signer": "%s",
+			"version": -1879048191,
+			"type": %d,
+			"maxFee": [0, 0],
+			"deadline": [1094650402, 17],
+			"driveKey": "%s",
+			"rootHash": "%064X",
+			"xorRootHash": "%064X",
+			"addActionsCount": 1,
+			"removeActionsCount": 0,
+			"addActions": [{"fileHash": "%s", "fileSize": [%d, 0]}],
+			"removeActions": []
+		}
+	}`, height, height, height, 0, testHistoryCounterparty.PublicKey, uint(DriveFileSystem), testBalanceDrive.PublicKey,
+		1, 1, fileHash, fileSize)
+}
+
+func TestBalanceChangeDecoder_FilesDeposit(t *testing.T) {
+	mock := newSdkMock(0)
+	defer mock.Close()
+
+	fileHash := &Hash{7}
+	requests := 0
+
+	// file is already removed from drive, so its size is known only from history of drive
+	mock.AddHandler(fmt.Sprintf(transactionsByGroupRoute, Confirmed), func(resp http.ResponseWriter, req *http.Request) {
+		requests++
+		assert.Equal(t, testBalanceDrive.Address.Address, req.URL.Query().Get("address"))
+		assert.Equal(t, fmt.Sprint(uint(DriveFileSystem)), req.URL.Query().Get("type[]"))
+		assert.Equal(t, "8", req.URL.Query().Get("toHeight"))
+
+		fmt.Fprintf(resp, `{"data": [%s], "pagination": {"totalEntries": 1, "pageNumber": 1, "pageSize": 100, "totalPages": 1}}`,
+			testBalanceFileSystemJson(4, fileHash, 300))
+	})
+
+	d := newBalanceChangeDecoder(mock.getPublicTestClientUnsafe(), testHistoryAccount.Address)
+	d.mosaicIds[StreamingNamespaceId.Id()] = testBalanceStorageId
+
+	deposit := &FilesDepositTransaction{
+		AbstractTransaction: newTestBalanceTx(8, testHistoryAccount),
+		DriveKey:            testBalanceDrive,
+		Files:               []*File{{FileHash: fileHash}, {FileHash: nil}},
+	}
+
+	for i := 0; i < 2; i++ {
+		changes, err := d.decodeFilesDeposit(ctx, deposit)
+		assert.Nil(t, err)
+		assert.Len(t, changes, 1)
+		assert.Equal(t, Amount(-300), changes[0].Amount)
+	}
+
+	// sizes of files are requested once per height
+	assert.Equal(t, 1, requests)
+}
+
+func TestAccountService_GetBalanceAtHeight(t *testing.T) {
+	mock := newSdkMock(0)
+	defer mock.Close()
+
+	mock.AddHandler(fmt.Sprintf(transactionsByGroupRoute, Confirmed), func(resp http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "7", req.URL.Query().Get("toHeight"))
+
+		fmt.Fprintf(resp, `{"data": [%s, %s], "pagination": {"totalEntries": 2, "pageNumber": 1, "pageSize": 100, "totalPages": 1}}`,
+			testHistoryTransferJson(7, testHistoryCounterparty, testHistoryAccount.Address, 200),
+			testHistoryTransferJson(5, testHistoryCounterparty, testHistoryAccount.Address, 300))
+	})
+
+	balance, err := mock.getPublicTestClientUnsafe().Account.GetBalanceAtHeight(ctx, testHistoryAccount.Address, testHistoryMosaicId, 7)
+	assert.Nil(t, err)
+	assert.Equal(t, Amount(500), balance)
+
+	_, err = mock.getPublicTestClientUnsafe().Account.GetBalanceAtHeight(ctx, testHistoryAccount.Address, testHistoryMosaicId, 0)
+	assert.Equal(t, ErrArgumentNotValid, err)
+}
@@ -0,0 +1,356 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/hex"
+	"io"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/proximax-storage/go-xpx-crypto"
+	"golang.org/x/crypto/scrypt"
+)
+
+const base32Alphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZ234567"
+
+// AccountGenerationProgress is a snapshot of GenerateAccounts state
+type AccountGenerationProgress struct {
+	Generated int
+	Requested int
+	Attempts  uint64
+	Elapsed   time.Duration
+}
+
+// AccountGenerationOptions configures GenerateAccounts
+type AccountGenerationOptions struct {
+	// Count of accounts to generate
+	Count int
+	// Prefix of base32 address, it includes the network letter, e.g. "VAB"
+	Prefix string
+	// Suffix of base32 address
+	Suffix string
+	// Workers count, runtime.NumCPU() is used when it is not positive
+	Workers int
+	// Progress is called every ProgressInterval and on every matched account
+	Progress         func(AccountGenerationProgress)
+	ProgressInterval time.Duration
+}
+
+func (o *AccountGenerationOptions) match(address string) bool {
+	return strings.HasPrefix(address, o.Prefix) && strings.HasSuffix(address, o.Suffix)
+}
+
+func (o *AccountGenerationOptions) validate(networkType NetworkType) error {
+	if o.Count <= 0 {
+		return ErrInvalidAccountsCount
+	}
+
+	o.Prefix = strings.ToUpper(o.Prefix)
+	o.Suffix = strings.ToUpper(o.Suffix)
+
+	for _, r := range o.Prefix + o.Suffix {
+		if !strings.ContainsRune(base32Alphabet, r) {
+			return ErrInvalidAddressPattern
+		}
+	}
+
+	if !reachablePrefix(o.Prefix, networkType) {
+		return ErrInvalidAddressPattern
+	}
+
+	return nil
+}
+
+// reachablePrefix returns true when address of network can start with prefix.
+// The first base32 character holds 5 high bits of network byte, the second one holds 3 low bits of it
+// followed by 2 bits of public key hash, so only 4 characters can occur there. Next characters are arbitrary
+func reachablePrefix(prefix string, networkType NetworkType) bool {
+	if len(prefix) > 0 && prefix[0] != base32Alphabet[networkType>>3] {
+		return false
+	}
+
+	if len(prefix) > 1 && NetworkType(strings.IndexByte(base32Alphabet, prefix[1])>>2) != networkType&0x07 {
+		return false
+	}
+
+	return true
+}
+
+// GenerateAccounts generates accounts on all CPUs until requested count of accounts matching prefix and suffix is found.
+// When context is done, accounts generated so far are returned with context error
+func GenerateAccounts(ctx context.Context, networkType NetworkType, generationHash *Hash, opts AccountGenerationOptions) ([]*Account, error) {
+	if err := opts.validate(networkType); err != nil {
+		return nil, err
+	}
+
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		attempts uint64
+		start    = time.Now()
+		found    = make(chan *Account, workers)
+		errs     = make(chan error, workers)
+		wg       sync.WaitGroup
+	)
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for ctx.Err() == nil {
+				acc, err := NewAccount(networkType, generationHash)
+				if err != nil {
+					errs <- err
+					return
+				}
+
+				atomic.AddUint64(&attempts, 1)
+
+				if !opts.match(acc.Address.Address) {
+					continue
+				}
+
+				select {
+				case found <- acc:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(found)
+	}()
+
+	progress := func(generated int) {
+		if opts.Progress != nil {
+			opts.Progress(AccountGenerationProgress{
+				Generated: generated,
+				Requested: opts.Count,
+				Attempts:  atomic.LoadUint64(&attempts),
+				Elapsed:   time.Since(start),
+			})
+		}
+	}
+
+	var tick <-chan time.Time
+	if opts.Progress != nil && opts.ProgressInterval > 0 {
+		ticker := time.NewTicker(opts.ProgressInterval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	accounts := make([]*Account, 0, opts.Count)
+	for {
+		select {
+		case acc, ok := <-found:
+			if !ok {
+				return accounts, ctx.Err()
+			}
+
+			accounts = append(accounts, acc)
+			progress(len(accounts))
+
+			if len(accounts) == opts.Count {
+				return accounts, nil
+			}
+		case err := <-errs:
+			return accounts, err
+		case <-tick:
+			progress(len(accounts))
+		case <-ctx.Done():
+			return accounts, ctx.Err()
+		}
+	}
+}
+
+// WriteAccountsCSV writes address, public key and private key of every account in CSV format
+func WriteAccountsCSV(w io.Writer, accounts []*Account) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write([]string{"address", "publicKey", "privateKey"}); err != nil {
+		return err
+	}
+
+	for _, acc := range accounts {
+		if err := cw.Write([]string{acc.Address.Address, acc.PublicAccount.PublicKey, acc.KeyPair.PrivateKey.String()}); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+const (
+	keystoreVersion = 1
+	keystoreCipher  = "aes-256-gcm"
+	keystoreKdf     = "scrypt"
+)
+
+// KeystoreScryptParams are parameters of key derivation from password
+type KeystoreScryptParams struct {
+	N int `json:"n"`
+	R int `json:"r"`
+	P int `json:"p"`
+}
+
+var DefaultKeystoreScryptParams = KeystoreScryptParams{N: 1 << 18, R: 8, P: 1}
+
+type keystoreCrypto struct {
+	Cipher     string               `json:"cipher"`
+	CipherText string               `json:"ciphertext"`
+	Nonce      string               `json:"nonce"`
+	Kdf        string               `json:"kdf"`
+	KdfParams  KeystoreScryptParams `json:"kdfparams"`
+	Salt       string               `json:"salt"`
+}
+
+// AccountKeystore is a password protected private key of account
+type AccountKeystore struct {
+	Version     int            `json:"version"`
+	Address     string         `json:"address"`
+	PublicKey   string         `json:"publicKey"`
+	NetworkType NetworkType    `json:"networkType"`
+	Crypto      keystoreCrypto `json:"crypto"`
+}
+
+// NewAccountKeystore encrypts account private key with key derived from password by scrypt.
+// DefaultKeystoreScryptParams are used when params are nil
+func NewAccountKeystore(account *Account, password string, params *KeystoreScryptParams) (*AccountKeystore, error) {
+	if account == nil || account.KeyPair == nil {
+		return nil, ErrNilAccount
+	}
+
+	if params == nil {
+		params = &DefaultKeystoreScryptParams
+	}
+
+	salt, err := randomBytes(32)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := scrypt.Key([]byte(password), salt, params.N, params.R, params.P, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, cipherText, err := sealAesGcm(key, account.KeyPair.PrivateKey.Raw, []byte(account.PublicAccount.PublicKey))
+	if err != nil {
+		return nil, err
+	}
+
+	return &AccountKeystore{
+		Version:     keystoreVersion,
+		Address:     account.Address.Address,
+		PublicKey:   account.PublicAccount.PublicKey,
+		NetworkType: account.Address.Type,
+		Crypto: keystoreCrypto{
+			Cipher:     keystoreCipher,
+			CipherText: hex.EncodeToString(cipherText),
+			Nonce:      hex.EncodeToString(nonce),
+			Kdf:        keystoreKdf,
+			KdfParams:  *params,
+			Salt:       hex.EncodeToString(salt),
+		},
+	}, nil
+}
+
+// Decrypt returns Account stored in keystore
+func (k *AccountKeystore) Decrypt(password string, generationHash *Hash) (*Account, error) {
+	if k.Version != keystoreVersion || k.Crypto.Cipher != keystoreCipher || k.Crypto.Kdf != keystoreKdf {
+		return nil, ErrInvalidKeystore
+	}
+
+	salt, err := hex.DecodeString(k.Crypto.Salt)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := hex.DecodeString(k.Crypto.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	cipherText, err := hex.DecodeString(k.Crypto.CipherText)
+	if err != nil {
+		return nil, err
+	}
+
+	params := k.Crypto.KdfParams
+	key, err := scrypt.Key([]byte(password), salt, params.N, params.R, params.P, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	// AES-GCM authenticates cipher text, so failure means wrong password or corrupted keystore
+	raw, err := openAesGcm(key, nonce, cipherText, []byte(k.PublicKey))
+	if err != nil {
+		return nil, ErrInvalidKeystorePassword
+	}
+
+	acc, err := NewAccountFromPrivateKey(crypto.NewPrivateKey(raw).String(), k.NetworkType, generationHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if acc.PublicAccount.PublicKey != k.PublicKey {
+		return nil, ErrInvalidKeystore
+	}
+
+	return acc, nil
+}
+
+// WriteAccountsKeystore writes JSON array of keystores of every account encrypted with the same password
+func WriteAccountsKeystore(w io.Writer, accounts []*Account, password string, params *KeystoreScryptParams) error {
+	keystores := make([]*AccountKeystore, len(accounts))
+
+	for i, acc := range accounts {
+		ks, err := NewAccountKeystore(acc, password, params)
+		if err != nil {
+			return err
+		}
+
+		keystores[i] = ks
+	}
+
+	return json.NewEncoder(w).Encode(keystores)
+}
+
+// ReadAccountsKeystore reads JSON array of keystores written by WriteAccountsKeystore
+func ReadAccountsKeystore(r io.Reader, password string, generationHash *Hash) ([]*Account, error) {
+	keystores := make([]*AccountKeystore, 0)
+	if err := json.NewDecoder(r).Decode(&keystores); err != nil {
+		return nil, err
+	}
+
+	accounts := make([]*Account, len(keystores))
+	for i, ks := range keystores {
+		acc, err := ks.Decrypt(password, generationHash)
+		if err != nil {
+			return nil, err
+		}
+
+		accounts[i] = acc
+	}
+
+	return accounts, nil
+}
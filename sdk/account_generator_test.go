@@ -0,0 +1,94 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testKeystoreScryptParams = &KeystoreScryptParams{N: 1 << 10, R: 8, P: 1}
+
+func TestGenerateAccounts_Pattern(t *testing.T) {
+	progressCalls := 0
+	accounts, err := GenerateAccounts(ctx, PublicTest, &Hash{}, AccountGenerationOptions{
+		Count:  3,
+		Prefix: "va",
+		Suffix: "b",
+		Progress: func(p AccountGenerationProgress) {
+			progressCalls++
+			assert.Equal(t, 3, p.Requested)
+		},
+	})
+	assert.Nil(t, err)
+	assert.Len(t, accounts, 3)
+	assert.Equal(t, 3, progressCalls)
+
+	for _, acc := range accounts {
+		assert.True(t, strings.HasPrefix(acc.Address.Address, "VA"))
+		assert.True(t, strings.HasSuffix(acc.Address.Address, "B"))
+	}
+}
+
+func TestGenerateAccounts_InvalidOptions(t *testing.T) {
+	_, err := GenerateAccounts(ctx, PublicTest, &Hash{}, AccountGenerationOptions{Count: 0})
+	assert.Equal(t, ErrInvalidAccountsCount, err)
+
+	_, err = GenerateAccounts(ctx, PublicTest, &Hash{}, AccountGenerationOptions{Count: 1, Suffix: "01"})
+	assert.Equal(t, ErrInvalidAddressPattern, err)
+
+	_, err = GenerateAccounts(ctx, PublicTest, &Hash{}, AccountGenerationOptions{Count: 1, Prefix: "SA"})
+	assert.Equal(t, ErrInvalidAddressPattern, err)
+
+	// only VA-VD can occur on public test network
+	_, err = GenerateAccounts(ctx, PublicTest, &Hash{}, AccountGenerationOptions{Count: 1, Prefix: "VZ"})
+	assert.Equal(t, ErrInvalidAddressPattern, err)
+
+	assert.True(t, reachablePrefix("VD", PublicTest))
+	assert.False(t, reachablePrefix("VE", PublicTest))
+	assert.True(t, reachablePrefix("MA", Mijin))
+}
+
+func TestGenerateAccounts_Cancel(t *testing.T) {
+	cancelCtx, cancel := context.WithCancel(ctx)
+	cancel()
+
+	// the pattern can not be found in reasonable time, so only cancellation stops generation
+	_, err := GenerateAccounts(cancelCtx, PublicTest, &Hash{}, AccountGenerationOptions{Count: 1, Suffix: "AAAAAAAAAAAA"})
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestWriteAccountsCSV(t *testing.T) {
+	accounts, err := GenerateAccounts(ctx, PublicTest, &Hash{}, AccountGenerationOptions{Count: 2})
+	assert.Nil(t, err)
+
+	buf := &bytes.Buffer{}
+	assert.Nil(t, WriteAccountsCSV(buf, accounts))
+
+	records, err := csv.NewReader(buf).ReadAll()
+	assert.Nil(t, err)
+	assert.Len(t, records, 3)
+	assert.Equal(t, accounts[1].Address.Address, records[2][0])
+	assert.Equal(t, accounts[1].PublicAccount.PublicKey, records[2][1])
+	assert.Equal(t, accounts[1].KeyPair.PrivateKey.String(), records[2][2])
+}
+
+func TestAccountsKeystore(t *testing.T) {
+	accounts, err := GenerateAccounts(ctx, PublicTest, &Hash{}, AccountGenerationOptions{Count: 2})
+	assert.Nil(t, err)
+
+	buf := &bytes.Buffer{}
+	assert.Nil(t, WriteAccountsKeystore(buf, accounts, "password", testKeystoreScryptParams))
+	assert.False(t, strings.Contains(buf.String(), accounts[0].KeyPair.PrivateKey.String()))
+
+	data := buf.Bytes()
+	restored, err := ReadAccountsKeystore(bytes.NewReader(data), "password", &Hash{})
+	assert.Nil(t, err)
+	assert.Equal(t, accounts, restored)
+
+	_, err = ReadAccountsKeystore(bytes.NewReader(data), "wrong", &Hash{})
+	assert.Equal(t, ErrInvalidKeystorePassword, err)
+}
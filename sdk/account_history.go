@@ -0,0 +1,388 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"context"
+	"encoding/binary"
+	"sort"
+)
+
+// GetAccountHistory returns chronological timeline of confirmed transactions where address is a signer,
+// a recipient or a participant of inner aggregate transaction, with balance changes of every mosaic.
+// Node doesn't provide levy of mosaic at past height, so levies are charged by current levy and marked as estimated
+func (a *AccountService) GetAccountHistory(ctx context.Context, address *Address, opts *AccountHistoryOptions) (*AccountHistory, error) {
+	if address == nil {
+		return nil, ErrNilAddress
+	}
+
+	if opts == nil {
+		opts = &AccountHistoryOptions{}
+	}
+
+	txs, err := a.getAccountTransactions(ctx, address, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := newBalanceChangeDecoder(a.client, address)
+
+	entries := make([]*AccountHistoryEntry, 0, len(txs))
+	for _, tx := range txs {
+		changes, err := decoder.decode(ctx, tx)
+		if err != nil {
+			return nil, err
+		}
+
+		info := tx.GetAbstractTransaction().TransactionInfo
+		entries = append(entries, &AccountHistoryEntry{
+			Height:      info.Height,
+			Index:       info.Index,
+			Transaction: tx,
+			Changes:     changes,
+		})
+	}
+
+	return newAccountHistory(address, entries, opts.InitialBalances), nil
+}
+
+// getAccountTransactions pages through confirmed transactions of address in ascending order of heights
+func (a *AccountService) getAccountTransactions(ctx context.Context, address *Address, opts *AccountHistoryOptions) ([]Transaction, error) {
+	pageSize := opts.PageSize
+	if pageSize == 0 {
+		pageSize = defaultAccountHistoryPageSize
+	}
+
+	// address filter of REST matches signer, recipient and participants of embedded transactions
+	tpOpts := &TransactionsPageOptions{
+		FromHeight: uint64(opts.FromHeight),
+		ToHeight:   uint64(opts.ToHeight),
+		Address:    address.Address,
+		PaginationOrderingOptions: PaginationOrderingOptions{
+			PageSize:      pageSize,
+			SortField:     "meta.height",
+			SortDirection: ASC.String(),
+		},
+	}
+
+	it := a.client.Transaction.IterateTransactionsByGroup(ctx, Confirmed, tpOpts, nil)
+	defer it.Close()
+
+	txs := make([]Transaction, 0)
+	seen := make(map[Hash]bool)
+	for it.Next() {
+		tx := it.Transaction()
+		if hash := tx.GetAbstractTransaction().TransactionHash; hash != nil {
+			if seen[*hash] {
+				continue
+			}
+			seen[*hash] = true
+		}
+
+		txs = append(txs, tx)
+	}
+
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(txs, func(i, j int) bool {
+		a, b := txs[i].GetAbstractTransaction(), txs[j].GetAbstractTransaction()
+		if a.Height != b.Height {
+			return a.Height < b.Height
+		}
+
+		return a.Index < b.Index
+	})
+
+	return txs, nil
+}
+
+// balanceChangeDecoder extracts balance changes of account from transactions.
+// Aliases, levies, drives and fee multipliers are requested once and cached.
+// Deposits of locks, offers and drives are tracked, so transactions must be decoded in chronological order
+type balanceChangeDecoder struct {
+	client         *Client
+	address        *Address
+	mosaicIds      map[uint64]*MosaicId
+	addresses      map[uint64]*Address
+	levies         map[MosaicId]*MosaicLevy
+	feeMultipliers map[Height]uint32
+	drives         map[string]*Drive
+	fileSizes      map[string]map[Hash]StorageSize
+	fileSizesTo    map[string]Height
+	hashLocks      map[Hash]*Mosaic
+	secretLocks    map[string]*secretLockDeposit
+	offers         map[offerDepositKey]*Mosaic
+	driveDeposits  map[string]*Mosaic
+}
+
+func newBalanceChangeDecoder(client *Client, address *Address) *balanceChangeDecoder {
+	return &balanceChangeDecoder{
+		client:         client,
+		address:        address,
+		mosaicIds:      make(map[uint64]*MosaicId),
+		addresses:      make(map[uint64]*Address),
+		levies:         make(map[MosaicId]*MosaicLevy),
+		feeMultipliers: make(map[Height]uint32),
+		drives:         make(map[string]*Drive),
+		fileSizes:      make(map[string]map[Hash]StorageSize),
+		fileSizesTo:    make(map[string]Height),
+		hashLocks:      make(map[Hash]*Mosaic),
+		secretLocks:    make(map[string]*secretLockDeposit),
+		offers:         make(map[offerDepositKey]*Mosaic),
+		driveDeposits:  make(map[string]*Mosaic),
+	}
+}
+
+func (d *balanceChangeDecoder) isOwn(address *Address) bool {
+	return address != nil && address.Address == d.address.Address
+}
+
+func (d *balanceChangeDecoder) isSigner(tx Transaction) bool {
+	signer := tx.GetAbstractTransaction().Signer
+	return signer != nil && d.isOwn(signer.Address)
+}
+
+// decode returns balance changes of top level transaction including inner transactions and fee
+func (d *balanceChangeDecoder) decode(ctx context.Context, tx Transaction) ([]*BalanceChange, error) {
+	changes := make([]*BalanceChange, 0)
+
+	if d.isSigner(tx) {
+		fee, err := d.fee(ctx, tx)
+		if err != nil {
+			return nil, err
+		}
+
+		if fee > 0 {
+			feeMosaicId, err := d.mosaicId(ctx, XpxNamespaceId)
+			if err != nil {
+				return nil, err
+			}
+
+			changes = append(changes, &BalanceChange{
+				Type:        FeeBalanceChange,
+				MosaicId:    feeMosaicId,
+				Amount:      -fee,
+				Transaction: tx,
+			})
+		}
+	}
+
+	if aggTx, ok := tx.(*AggregateTransaction); ok {
+		if aggTx.Type == AggregateBonded {
+			changes = append(changes, d.decodeHashLockRelease(aggTx)...)
+		}
+
+		for _, inner := range aggTx.InnerTransactions {
+			innerChanges, err := d.decodeInner(ctx, inner)
+			if err != nil {
+				return nil, err
+			}
+
+			changes = append(changes, innerChanges...)
+		}
+
+		return changes, nil
+	}
+
+	innerChanges, err := d.decodeInner(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(changes, innerChanges...), nil
+}
+
+// decodeInner returns balance changes of transaction without fee
+func (d *balanceChangeDecoder) decodeInner(ctx context.Context, tx Transaction) ([]*BalanceChange, error) {
+	switch tx := tx.(type) {
+	case *TransferTransaction:
+		return d.decodeTransfer(ctx, tx)
+	case *LockFundsTransaction:
+		return d.decodeHashLock(ctx, tx)
+	case *SecretLockTransaction:
+		return d.decodeSecretLock(ctx, tx)
+	case *SecretProofTransaction:
+		return d.decodeSecretProof(ctx, tx)
+	case *AddExchangeOfferTransaction:
+		return d.decodeAddExchangeOffer(ctx, tx)
+	case *ExchangeOfferTransaction:
+		return d.decodeExchangeOffer(ctx, tx)
+	case *RemoveExchangeOfferTransaction:
+		return d.decodeRemoveExchangeOffer(ctx, tx)
+	case *JoinToDriveTransaction:
+		return d.decodeJoinToDrive(ctx, tx)
+	case *FilesDepositTransaction:
+		return d.decodeFilesDeposit(ctx, tx)
+	case *EndDriveTransaction:
+		return d.decodeEndDrive(ctx, tx)
+	case *StartFileDownloadTransaction:
+		return d.decodeStartFileDownload(ctx, tx)
+	}
+
+	return nil, nil
+}
+
+func (d *balanceChangeDecoder) decodeTransfer(ctx context.Context, tx *TransferTransaction) ([]*BalanceChange, error) {
+	recipient, err := d.resolveAddress(ctx, tx.Recipient)
+	if err != nil {
+		return nil, err
+	}
+
+	sender := tx.Signer.Address
+	isSender, isRecipient := d.isOwn(sender), d.isOwn(recipient)
+	changes := make([]*BalanceChange, 0)
+
+	for _, m := range tx.Mosaics {
+		mosaicId, err := d.mosaicId(ctx, m.AssetId)
+		if err != nil {
+			return nil, err
+		}
+
+		if isSender != isRecipient {
+			change := &BalanceChange{
+				Type:         TransferBalanceChange,
+				MosaicId:     mosaicId,
+				Amount:       m.Amount,
+				Counterparty: sender,
+				Transaction:  tx,
+			}
+
+			if isSender {
+				change.Amount, change.Counterparty = -m.Amount, recipient
+			}
+
+			changes = append(changes, change)
+		}
+
+		levy, err := d.levy(ctx, mosaicId)
+		if err != nil {
+			return nil, err
+		}
+
+		amount, err := levy.LevyOf(m.Amount)
+		if err != nil {
+			return nil, err
+		}
+
+		if amount == 0 {
+			continue
+		}
+
+		if isSender {
+			changes = append(changes, &BalanceChange{
+				Type:         LevyBalanceChange,
+				MosaicId:     levy.MosaicId,
+				Amount:       -amount,
+				Counterparty: levy.Recipient,
+				Transaction:  tx,
+				Estimated:    true,
+			})
+		}
+
+		if d.isOwn(levy.Recipient) {
+			changes = append(changes, &BalanceChange{
+				Type:         LevyBalanceChange,
+				MosaicId:     levy.MosaicId,
+				Amount:       amount,
+				Counterparty: sender,
+				Transaction:  tx,
+				Estimated:    true,
+			})
+		}
+	}
+
+	return changes, nil
+}
+
+// mosaicId returns MosaicId of asset, namespace is resolved by its current alias
+func (d *balanceChangeDecoder) mosaicId(ctx context.Context, assetId AssetId) (*MosaicId, error) {
+	if assetId == nil {
+		return nil, ErrNilAssetId
+	}
+
+	if assetId.Type() == MosaicAssetIdType {
+		return assetId.(*MosaicId), nil
+	}
+
+	if mosaicId, ok := d.mosaicIds[assetId.Id()]; ok {
+		return mosaicId, nil
+	}
+
+	mosaicId, err := d.client.Namespace.GetLinkedMosaicId(ctx, assetId.(*NamespaceId))
+	if err != nil {
+		return nil, err
+	}
+
+	d.mosaicIds[assetId.Id()] = mosaicId
+	return mosaicId, nil
+}
+
+// resolveAddress returns address linked to namespace when passed address is an alias
+func (d *balanceChangeDecoder) resolveAddress(ctx context.Context, address *Address) (*Address, error) {
+	if address == nil || address.Type != AliasAddress {
+		return address, nil
+	}
+
+	raw, err := address.Decode()
+	if err != nil {
+		return nil, err
+	}
+
+	namespaceId := newNamespaceIdPanic(binary.LittleEndian.Uint64(raw[1:9]))
+	if linked, ok := d.addresses[namespaceId.Id()]; ok {
+		return linked, nil
+	}
+
+	linked, err := d.client.Namespace.GetLinkedAddress(ctx, namespaceId)
+	if err != nil {
+		return nil, err
+	}
+
+	d.addresses[namespaceId.Id()] = linked
+	return linked, nil
+}
+
+// levy returns current levy of mosaic or nil when mosaic doesn't have levy
+func (d *balanceChangeDecoder) levy(ctx context.Context, mosaicId *MosaicId) (*MosaicLevy, error) {
+	if levy, ok := d.levies[*mosaicId]; ok {
+		return levy, nil
+	}
+
+	levy, err := d.client.Mosaic.GetMosaicLevy(ctx, mosaicId)
+	if err != nil {
+		if !isNotFoundError(err) {
+			return nil, err
+		}
+
+		levy = nil
+	}
+
+	if levy != nil && levy.Type == LevyNone {
+		levy = nil
+	}
+
+	d.levies[*mosaicId] = levy
+	return levy, nil
+}
+
+// fee returns effective fee of transaction which depends on fee multiplier of block
+func (d *balanceChangeDecoder) fee(ctx context.Context, tx Transaction) (Amount, error) {
+	height := tx.GetAbstractTransaction().Height
+
+	multiplier, ok := d.feeMultipliers[height]
+	if !ok {
+		block, err := d.client.Blockchain.GetBlockByHeight(ctx, height)
+		if err != nil {
+			return 0, err
+		}
+
+		multiplier = block.FeeMultiplier
+		d.feeMultipliers[height] = multiplier
+	}
+
+	return effectiveFee(tx, multiplier), nil
+}
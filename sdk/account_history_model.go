@@ -0,0 +1,190 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/proximax-storage/go-xpx-utils/str"
+)
+
+type BalanceChangeType uint8
+
+const (
+	TransferBalanceChange BalanceChangeType = iota
+	LevyBalanceChange
+	FeeBalanceChange
+	LockBalanceChange
+	SecretLockBalanceChange
+	ExchangeBalanceChange
+	StorageDepositBalanceChange
+	DownloadBalanceChange
+)
+
+func (t BalanceChangeType) String() string {
+	switch t {
+	case TransferBalanceChange:
+		return "Transfer"
+	case LevyBalanceChange:
+		return "Levy"
+	case FeeBalanceChange:
+		return "Fee"
+	case LockBalanceChange:
+		return "Lock"
+	case SecretLockBalanceChange:
+		return "SecretLock"
+	case ExchangeBalanceChange:
+		return "Exchange"
+	case StorageDepositBalanceChange:
+		return "StorageDeposit"
+	case DownloadBalanceChange:
+		return "Download"
+	}
+
+	return fmt.Sprintf("%d", t)
+}
+
+// BalanceChange is a change of account balance of a single mosaic caused by transaction.
+// Amount is negative when mosaics are debited from account
+type BalanceChange struct {
+	Type         BalanceChangeType
+	MosaicId     *MosaicId
+	Amount       Amount
+	Counterparty *Address
+	// Transaction which causes change, it is inner transaction for aggregates
+	Transaction Transaction
+	// Balance of mosaic after change
+	Balance Amount
+	// Estimated is true when amount is derived from current state of chain instead of state at height of transaction.
+	// Levies are charged by current levy of mosaic, which could be changed or removed after transfer
+	Estimated bool
+}
+
+func (c *BalanceChange) String() string {
+	return str.StructToString(
+		"BalanceChange",
+		str.NewField("Type", str.StringPattern, c.Type),
+		str.NewField("MosaicId", str.StringPattern, c.MosaicId),
+		str.NewField("Amount", str.IntPattern, int64(c.Amount)),
+		str.NewField("Counterparty", str.StringPattern, c.Counterparty),
+		str.NewField("Balance", str.IntPattern, int64(c.Balance)),
+		str.NewField("Estimated", str.BooleanPattern, c.Estimated),
+	)
+}
+
+// AccountHistoryEntry is a confirmed transaction where account participates
+type AccountHistoryEntry struct {
+	Height      Height
+	Index       uint32
+	Transaction Transaction
+	Changes     []*BalanceChange
+}
+
+func (e *AccountHistoryEntry) String() string {
+	return str.StructToString(
+		"AccountHistoryEntry",
+		str.NewField("Height", str.StringPattern, e.Height),
+		str.NewField("Index", str.IntPattern, e.Index),
+		str.NewField("Changes", str.StringPattern, e.Changes),
+	)
+}
+
+// AccountHistory is a chronological timeline of account transactions with running balances
+type AccountHistory struct {
+	Address  *Address
+	Entries  []*AccountHistoryEntry
+	Balances map[MosaicId]Amount
+}
+
+// Balance returns balance of mosaic after the last entry of history
+func (h *AccountHistory) Balance(mosaicId *MosaicId) Amount {
+	return h.Balances[*mosaicId]
+}
+
+// Changes returns every change of mosaic balance in chronological order
+func (h *AccountHistory) Changes(mosaicId *MosaicId) []*BalanceChange {
+	changes := make([]*BalanceChange, 0)
+
+	for _, e := range h.Entries {
+		for _, c := range e.Changes {
+			if c.MosaicId.Id() == mosaicId.Id() {
+				changes = append(changes, c)
+			}
+		}
+	}
+
+	return changes
+}
+
+// Estimated returns true when any change of mosaic balance is estimated, so balances of mosaic can differ from chain
+func (h *AccountHistory) Estimated(mosaicId *MosaicId) bool {
+	for _, c := range h.Changes(mosaicId) {
+		if c.Estimated {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (h *AccountHistory) String() string {
+	return str.StructToString(
+		"AccountHistory",
+		str.NewField("Address", str.StringPattern, h.Address),
+		str.NewField("Entries", str.StringPattern, h.Entries),
+		str.NewField("Balances", str.StringPattern, h.Balances),
+	)
+}
+
+// AccountHistoryOptions limits heights of AccountHistory.
+// InitialBalances are balances of account before FromHeight, they are zero by default
+type AccountHistoryOptions struct {
+	FromHeight      Height
+	ToHeight        Height
+	PageSize        uint64
+	InitialBalances map[MosaicId]Amount
+}
+
+const defaultAccountHistoryPageSize = 100
+
+// newAccountHistory orders entries chronologically and calculates running balances
+func newAccountHistory(address *Address, entries []*AccountHistoryEntry, initial map[MosaicId]Amount) *AccountHistory {
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Height != entries[j].Height {
+			return entries[i].Height < entries[j].Height
+		}
+
+		return entries[i].Index < entries[j].Index
+	})
+
+	balances := make(map[MosaicId]Amount, len(initial))
+	for id, amount := range initial {
+		balances[id] = amount
+	}
+
+	for _, e := range entries {
+		for _, c := range e.Changes {
+			balances[*c.MosaicId] += c.Amount
+			c.Balance = balances[*c.MosaicId]
+		}
+	}
+
+	return &AccountHistory{
+		Address:  address,
+		Entries:  entries,
+		Balances: balances,
+	}
+}
+
+// effectiveFee returns fee paid for transaction in block with passed fee multiplier
+func effectiveFee(tx Transaction, feeMultiplier uint32) Amount {
+	fee := Amount(uint64(feeMultiplier) * uint64(tx.Size()))
+	if maxFee := tx.GetAbstractTransaction().MaxFee; fee > maxFee {
+		return maxFee
+	}
+
+	return fee
+}
@@ -0,0 +1,175 @@
+package sdk
+
+import (
+	"encoding/base32"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testHistoryAccount, _      = NewAccountFromPublicKey("ED7A848FDEB2321EE97CE8AF265588C54B4A58C72117247C7205EB061865055C", PublicTest)
+	testHistoryCounterparty, _ = NewAccountFromPublicKey("CFC31B3080B36BC3D59DF4AB936AC72F4DC15CE3C3E1B1EC5EA41415A4C33FEE", PublicTest)
+	testHistoryLevyAccount, _  = NewAccountFromPublicKey("36E7F50C8B8BC9A4FC6325B2359E0E5DB50C75A914B5292AD726FD5AE3992691", PublicTest)
+	testHistoryXpxId           = newMosaicIdPanic(0x0DC67FBE1CAD29E3)
+	testHistoryMosaicId        = newMosaicIdPanic(0x26514E2A1EF33824)
+)
+
+func newTestHistoryTransfer(height Height, signer *PublicAccount, recipient *Address, mosaics ...*Mosaic) *TransferTransaction {
+	return &TransferTransaction{
+		AbstractTransaction: AbstractTransaction{
+			TransactionInfo: TransactionInfo{Height: height},
+			Type:            Transfer,
+			MaxFee:          Amount(1000),
+			Signer:          signer,
+		},
+		Recipient: recipient,
+		Mosaics:   mosaics,
+		Message:   NewPlainMessage(""),
+	}
+}
+
+func newTestBalanceChangeDecoder() *balanceChangeDecoder {
+	d := newBalanceChangeDecoder(nil, testHistoryAccount.Address)
+	d.mosaicIds[XpxNamespaceId.Id()] = testHistoryXpxId
+	d.levies[*testHistoryXpxId] = nil
+	d.levies[*testHistoryMosaicId] = &MosaicLevy{
+		Type:      LevyPercentileFee,
+		Recipient: testHistoryLevyAccount.Address,
+		Fee:       CreateMosaicLevyFeePercentile(1.5),
+		MosaicId:  testHistoryXpxId,
+	}
+	d.feeMultipliers[10] = 1
+	d.feeMultipliers[20] = 1
+	return d
+}
+
+func TestLevyAmount(t *testing.T) {
+	for levy, expected := range map[*MosaicLevy]Amount{
+		nil:                             0,
+		{Type: LevyAbsoluteFee, Fee: 7}: 7,
+		{Type: LevyPercentileFee, Fee: CreateMosaicLevyFeePercentile(1.5)}: 15,
+	} {
+		amount, err := levy.LevyOf(1000)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, amount)
+	}
+
+	// intermediate product of percentile fee exceeds int64
+	amount, err := (&MosaicLevy{Type: LevyPercentileFee, Fee: CreateMosaicLevyFeePercentile(50)}).LevyOf(1 << 62)
+	assert.Nil(t, err)
+	assert.Equal(t, Amount(1<<61), amount)
+}
+
+func TestAccountHistory_RunningBalances(t *testing.T) {
+	d := newTestBalanceChangeDecoder()
+
+	received := newTestHistoryTransfer(10, testHistoryCounterparty, testHistoryAccount.Address,
+		newMosaicPanic(XpxNamespaceId, 10000), newMosaicPanic(testHistoryMosaicId, 1000))
+	sent := newTestHistoryTransfer(20, testHistoryAccount, testHistoryCounterparty.Address,
+		newMosaicPanic(testHistoryMosaicId, 400))
+
+	entries := make([]*AccountHistoryEntry, 0)
+	for _, tx := range []Transaction{sent, received} {
+		changes, err := d.decode(ctx, tx)
+		assert.Nil(t, err)
+		entries = append(entries, &AccountHistoryEntry{Height: tx.GetAbstractTransaction().Height, Transaction: tx, Changes: changes})
+	}
+
+	history := newAccountHistory(testHistoryAccount.Address, entries, nil)
+	assert.Equal(t, Height(10), history.Entries[0].Height)
+	assert.Equal(t, Height(20), history.Entries[1].Height)
+
+	// received transfer doesn't charge recipient with fee or levy
+	assert.Len(t, history.Entries[0].Changes, 2)
+
+	fee := effectiveFee(sent, 1)
+	xpxChanges := history.Changes(testHistoryXpxId)
+	assert.Len(t, xpxChanges, 3)
+	assert.Equal(t, FeeBalanceChange, xpxChanges[1].Type)
+	assert.Equal(t, -fee, xpxChanges[1].Amount)
+	assert.Equal(t, LevyBalanceChange, xpxChanges[2].Type)
+	assert.Equal(t, Amount(-6), xpxChanges[2].Amount)
+	assert.Equal(t, testHistoryLevyAccount.Address, xpxChanges[2].Counterparty)
+	assert.True(t, xpxChanges[2].Estimated)
+	assert.True(t, history.Estimated(testHistoryXpxId))
+	assert.False(t, history.Estimated(testHistoryMosaicId))
+
+	assert.Equal(t, Amount(10000)-fee-6, history.Balance(testHistoryXpxId))
+	assert.Equal(t, Amount(600), history.Balance(testHistoryMosaicId))
+	assert.Equal(t, Amount(600), history.Changes(testHistoryMosaicId)[1].Balance)
+}
+
+func TestAccountHistory_Aggregate(t *testing.T) {
+	d := newTestBalanceChangeDecoder()
+
+	aggTx := &AggregateTransaction{
+		AbstractTransaction: AbstractTransaction{
+			TransactionInfo: TransactionInfo{Height: 10},
+			Type:            AggregateCompleted,
+			Signer:          testHistoryCounterparty,
+		},
+		InnerTransactions: []Transaction{
+			newTestHistoryTransfer(10, testHistoryCounterparty, testHistoryAccount.Address, newMosaicPanic(XpxNamespaceId, 500)),
+			newTestHistoryTransfer(10, testHistoryAccount, testHistoryCounterparty.Address, newMosaicPanic(XpxNamespaceId, 200)),
+		},
+	}
+
+	changes, err := d.decode(ctx, aggTx)
+	assert.Nil(t, err)
+	assert.Len(t, changes, 2)
+	assert.Equal(t, Amount(500), changes[0].Amount)
+	assert.Equal(t, Amount(-200), changes[1].Amount)
+	assert.Equal(t, aggTx.InnerTransactions[1], changes[1].Transaction)
+}
+
+func testHistoryTransferJson(height int, signer *PublicAccount, recipient *Address, amount int) string {
+	raw, _ := base32.StdEncoding.DecodeString(recipient.Address)
+
+	return fmt.Sprintf(`{
+		"meta": {"height": [%d, 0], "hash": "%064X", "merkleComponentHash": "%064X", "index": 0, "id": "5B686E97F0C0EA00017B9437"},
+		"transaction": {
+			"signature": "%0128X",
+			"signer": "%s",
+			"version": -1879048189,
+			"type": 16724,
+			"maxFee": [0, 0],
+			"deadline": [1094650402, 17],
+			"recipient": "%s",
+			"message": {"type": 0, "payload": ""},
+			"mosaics": [{"id": [519256100, 642862634], "amount": [%d, 0]}]
+		}
+	}`, height, height, height, 0, signer.PublicKey, strings.ToUpper(hex.EncodeToString(raw)), amount)
+}
+
+func TestAccountService_GetAccountHistory(t *testing.T) {
+	mock := newSdkMock(0)
+	defer mock.Close()
+
+	pages := []string{
+		testHistoryTransferJson(5, testHistoryCounterparty, testHistoryAccount.Address, 300),
+		testHistoryTransferJson(7, testHistoryCounterparty, testHistoryAccount.Address, 200),
+	}
+
+	mock.AddHandler(fmt.Sprintf(transactionsByGroupRoute, Confirmed), func(resp http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, testHistoryAccount.Address.Address, req.URL.Query().Get("address"))
+
+		page := 1
+		fmt.Sscanf(req.URL.Query().Get("pageNumber"), "%d", &page)
+
+		fmt.Fprintf(resp, `{"data": [%s], "pagination": {"totalEntries": 2, "pageNumber": %d, "pageSize": 1, "totalPages": 2}}`, pages[page-1], page)
+	})
+
+	history, err := mock.getPublicTestClientUnsafe().Account.GetAccountHistory(ctx, testHistoryAccount.Address, &AccountHistoryOptions{
+		PageSize:        1,
+		InitialBalances: map[MosaicId]Amount{*testHistoryMosaicId: 1000},
+	})
+	assert.Nil(t, err)
+	assert.Len(t, history.Entries, 2)
+	assert.Equal(t, Amount(1500), history.Balance(testHistoryMosaicId))
+	assert.Equal(t, Amount(1300), history.Entries[0].Changes[0].Balance)
+}
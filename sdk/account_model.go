@@ -46,6 +46,36 @@ func (a *Account) EncryptMessage(message string, recipientPublicAccount *PublicA
 	return NewSecureMessageFromPlaintText(message, a.KeyPair.PrivateKey, rpk)
 }
 
+// EncryptMessageV2 returns SecureMessageV2 which can be decrypted by every passed recipient
+func (a *Account) EncryptMessageV2(message string, recipientPublicAccounts ...*PublicAccount) (*SecureMessage, error) {
+	rpks := make([]*crypto.PublicKey, len(recipientPublicAccounts))
+
+	for i, recipient := range recipientPublicAccounts {
+		if recipient == nil {
+			return nil, ErrNilAccount
+		}
+
+		rpk, err := crypto.NewPublicKeyfromHex(recipient.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+
+		rpks[i] = rpk
+	}
+
+	return NewSecureMessageV2FromPlainText(message, a.KeyPair.PrivateKey, rpks...)
+}
+
+// EncryptMessageForCosignatories returns SecureMessageV2 which can be decrypted by every cosignatory of multisig account
+func (a *Account) EncryptMessageForCosignatories(message string, multisigInfo *MultisigAccountInfo) (*SecureMessage, error) {
+	if multisigInfo == nil {
+		return nil, ErrNilAccount
+	}
+
+	return a.EncryptMessageV2(message, multisigInfo.Cosignatories...)
+}
+
+// DecryptMessage decrypts SecureMessageV2 as well as legacy secure message
 func (a *Account) DecryptMessage(encryptedMessage *SecureMessage, senderPublicAccount *PublicAccount) (*PlainMessage, error) {
 	spk, err := crypto.NewPublicKeyfromHex(senderPublicAccount.PublicKey)
 
@@ -0,0 +1,327 @@
+package sdk
+
+import (
+	"encoding/hex"
+
+	blst "github.com/supranational/blst/bindings/go"
+	"golang.org/x/crypto/sha3"
+)
+
+// Domain separation tag of proof of possession. Proofs are signatures of public key itself,
+// so they must not be reusable as signatures of ordinary messages
+const POP_DST = string("BLS_POP_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_")
+
+// Domain of key derivation from account private key, changing it changes every derived key
+const blsAccountKeyDomain = "xpx bls account key"
+
+const (
+	BLSPrivateKeySize = 32
+	BLSPublicKeySize  = 48
+	BLSSignatureSize  = 96
+)
+
+// BLSMessage is an arbitrary byte sequence which can be signed by BLSPrivateKey
+type BLSMessage []byte
+
+// NewBLSMessageFromHash returns BLSMessage of transaction hash
+func NewBLSMessageFromHash(hash *Hash) BLSMessage {
+	return BLSMessage(hash[:])
+}
+
+func (m BLSMessage) HexString() string {
+	return hex.EncodeToString(m)
+}
+
+// NewBLSKeyPairFromAccount deterministically derives BLS key pair from account private key.
+// The same account always produces the same BLS key pair
+func NewBLSKeyPairFromAccount(account *Account) (*KeyPair, error) {
+	if account == nil || account.KeyPair == nil || account.KeyPair.PrivateKey == nil {
+		return nil, ErrNilAccount
+	}
+
+	h := sha3.New256()
+	h.Write([]byte(blsAccountKeyDomain))
+	h.Write(account.KeyPair.PrivateKey.Raw)
+
+	var ikm [32]byte
+	copy(ikm[:], h.Sum(nil))
+
+	return GenerateKeyPairFromIKM(ikm), nil
+}
+
+// NewBLSPrivateKey returns BLSPrivateKey from raw bytes
+func NewBLSPrivateKey(b []byte) (BLSPrivateKey, error) {
+	if len(b) != BLSPrivateKeySize || BLSPrivateKey(b) == ZeroBLSPrivateKey {
+		return ZeroBLSPrivateKey, ErrInvalidBLSPrivateKey
+	}
+
+	return BLSPrivateKey(b), nil
+}
+
+// NewBLSPrivateKeyFromHex returns BLSPrivateKey from hex string
+func NewBLSPrivateKeyFromHex(s string) (BLSPrivateKey, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return ZeroBLSPrivateKey, err
+	}
+
+	return NewBLSPrivateKey(b)
+}
+
+// NewBLSPublicKey returns BLSPublicKey from compressed point and checks that point is a valid key
+func NewBLSPublicKey(b []byte) (BLSPublicKey, error) {
+	if len(b) != BLSPublicKeySize {
+		return ZeroBLSPublicKey, ErrInvalidBLSPublicKey
+	}
+
+	pk := new(blst.P1Affine).Uncompress(b)
+	if pk == nil || !pk.KeyValidate() {
+		return ZeroBLSPublicKey, ErrInvalidBLSPublicKey
+	}
+
+	return BLSPublicKey(b), nil
+}
+
+// NewBLSPublicKeyFromHex returns BLSPublicKey from hex string
+func NewBLSPublicKeyFromHex(s string) (BLSPublicKey, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return ZeroBLSPublicKey, err
+	}
+
+	return NewBLSPublicKey(b)
+}
+
+// NewBLSSignature returns BLSSignature from compressed point and checks that point is in the group
+func NewBLSSignature(b []byte) (BLSSignature, error) {
+	if len(b) != BLSSignatureSize {
+		return ZeroBLSSignature, ErrInvalidBLSSignature
+	}
+
+	sig := new(blst.P2Affine).Uncompress(b)
+	if sig == nil || !sig.SigValidate(false) {
+		return ZeroBLSSignature, ErrInvalidBLSSignature
+	}
+
+	return BLSSignature(b), nil
+}
+
+// NewBLSSignatureFromHex returns BLSSignature from hex string
+func NewBLSSignatureFromHex(s string) (BLSSignature, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return ZeroBLSSignature, err
+	}
+
+	return NewBLSSignature(b)
+}
+
+func (priv BLSPrivateKey) Bytes() []byte {
+	return []byte(priv)
+}
+
+func (priv BLSPrivateKey) SignMessage(msg BLSMessage) BLSSignature {
+	return priv.Sign(string(msg))
+}
+
+// ProofOfPossession returns signature of own public key.
+// Verifiers should require it before accepting public key into aggregation to prevent rogue key attacks
+func (priv BLSPrivateKey) ProofOfPossession() BLSSignature {
+	return BLSSignature(new(blst.P2Affine).Sign(priv.sk(), []byte(priv.Public()), []byte(POP_DST)).Compress())
+}
+
+func (pub BLSPublicKey) Bytes() []byte {
+	return []byte(pub)
+}
+
+func (pub BLSPublicKey) VerifyMessage(msg BLSMessage, signature BLSSignature) bool {
+	pk := new(blst.P1Affine).Uncompress([]byte(pub))
+	sig := new(blst.P2Affine).Uncompress([]byte(signature))
+	if pk == nil || sig == nil {
+		return false
+	}
+
+	return sig.Verify(true, pk, true, msg, []byte(FILECOIN_DST))
+}
+
+// VerifyProofOfPossession checks that proof was produced by the owner of public key
+func (pub BLSPublicKey) VerifyProofOfPossession(proof BLSSignature) bool {
+	pk := new(blst.P1Affine).Uncompress([]byte(pub))
+	sig := new(blst.P2Affine).Uncompress([]byte(proof))
+	if pk == nil || sig == nil {
+		return false
+	}
+
+	return sig.Verify(true, pk, true, []byte(pub), []byte(POP_DST))
+}
+
+func (pub BLSPublicKey) MarshalJSON() ([]byte, error) {
+	return json.Marshal(pub.HexString())
+}
+
+func (pub *BLSPublicKey) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	key, err := NewBLSPublicKeyFromHex(s)
+	if err != nil {
+		return err
+	}
+
+	*pub = key
+	return nil
+}
+
+func (signature BLSSignature) Bytes() []byte {
+	return []byte(signature)
+}
+
+func (signature BLSSignature) MarshalJSON() ([]byte, error) {
+	return json.Marshal(signature.HexString())
+}
+
+func (signature *BLSSignature) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	sig, err := NewBLSSignatureFromHex(s)
+	if err != nil {
+		return err
+	}
+
+	*signature = sig
+	return nil
+}
+
+func (p *KeyPair) SignMessage(msg BLSMessage) BLSSignature {
+	return p.PrivateKey.SignMessage(msg)
+}
+
+func (p *KeyPair) ProofOfPossession() BLSSignature {
+	return p.PrivateKey.ProofOfPossession()
+}
+
+// SignTransactionHash returns signature of transaction hash which can be aggregated into BLSAttestation
+func (p *KeyPair) SignTransactionHash(hash *Hash) BLSSignature {
+	return p.SignMessage(NewBLSMessageFromHash(hash))
+}
+
+// BLSCommitteeMember is a public key with its proof of possession
+type BLSCommitteeMember struct {
+	PublicKey BLSPublicKey `json:"publicKey"`
+	Proof     BLSSignature `json:"proof"`
+}
+
+// NewBLSCommitteeMember returns member of committee with proof of possession produced by key pair
+func NewBLSCommitteeMember(keyPair *KeyPair) *BLSCommitteeMember {
+	return &BLSCommitteeMember{
+		PublicKey: keyPair.PublicKey,
+		Proof:     keyPair.ProofOfPossession(),
+	}
+}
+
+// BLSCommittee is a set of public keys with verified proofs of possession.
+// Only keys of committee are accepted in aggregated attestations
+type BLSCommittee struct {
+	members map[BLSPublicKey]*BLSCommitteeMember
+}
+
+// NewBLSCommittee returns committee when proof of possession of every member is valid
+func NewBLSCommittee(members ...*BLSCommitteeMember) (*BLSCommittee, error) {
+	c := &BLSCommittee{members: make(map[BLSPublicKey]*BLSCommitteeMember, len(members))}
+
+	for _, m := range members {
+		if m == nil {
+			return nil, ErrNilAccount
+		}
+
+		if !m.PublicKey.VerifyProofOfPossession(m.Proof) {
+			return nil, ErrInvalidProofOfPossession
+		}
+
+		c.members[m.PublicKey] = m
+	}
+
+	return c, nil
+}
+
+func (c *BLSCommittee) Size() int {
+	return len(c.members)
+}
+
+func (c *BLSCommittee) IsMember(key BLSPublicKey) bool {
+	_, ok := c.members[key]
+	return ok
+}
+
+// VerifyAttestation checks that every signer of attestation belongs to committee and aggregated signature is valid
+func (c *BLSCommittee) VerifyAttestation(attestation *BLSAttestation) error {
+	if attestation == nil || attestation.Hash == nil || len(attestation.Signers) == 0 {
+		return ErrInvalidBLSSignature
+	}
+
+	seen := make(map[BLSPublicKey]bool, len(attestation.Signers))
+	for _, signer := range attestation.Signers {
+		if !c.IsMember(signer) {
+			return ErrUnknownBLSCommitteeMember
+		}
+
+		if seen[signer] {
+			return ErrInvalidBLSSignature
+		}
+		seen[signer] = true
+	}
+
+	if !attestation.Verify() {
+		return ErrInvalidBLSSignature
+	}
+
+	return nil
+}
+
+// BLSAttestation is an aggregated signature of transaction hash produced by several signers
+type BLSAttestation struct {
+	Hash      *Hash          `json:"hash"`
+	Signers   []BLSPublicKey `json:"signers"`
+	Signature BLSSignature   `json:"signature"`
+}
+
+// NewBLSAttestation aggregates signatures of signers over transaction hash.
+// Signers and signatures should be in the same order
+func NewBLSAttestation(hash *Hash, signers []BLSPublicKey, signatures []BLSSignature) (*BLSAttestation, error) {
+	if hash == nil {
+		return nil, ErrNilHash
+	}
+
+	if len(signers) != len(signatures) || len(signers) == 0 {
+		return nil, ErrBLSSignersSignaturesLength
+	}
+
+	msg := NewBLSMessageFromHash(hash)
+	for i, signer := range signers {
+		if !signer.VerifyMessage(msg, signatures[i]) {
+			return nil, ErrInvalidBLSSignature
+		}
+	}
+
+	signature, err := AggregateSignatures(signatures...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BLSAttestation{
+		Hash:      hash,
+		Signers:   append([]BLSPublicKey{}, signers...),
+		Signature: signature,
+	}, nil
+}
+
+// Verify checks aggregated signature against signers.
+// It does not check proofs of possession, so BLSCommittee.VerifyAttestation should be used for untrusted signers
+func (a *BLSAttestation) Verify() bool {
+	return FastAggregateVerify(a.Signers, string(NewBLSMessageFromHash(a.Hash)), a.Signature)
+}
@@ -0,0 +1,105 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBLSKeyPairFromAccount(t *testing.T) {
+	account, err := NewAccountFromPrivateKey("A97B139EB641BCC841A610231870925EB301BA680D07BBCF9AEE83FAA5E9FB43", PublicTest, &Hash{})
+	assert.Nil(t, err)
+
+	kp1, err := NewBLSKeyPairFromAccount(account)
+	assert.Nil(t, err)
+	kp2, err := NewBLSKeyPairFromAccount(account)
+	assert.Nil(t, err)
+	assert.Equal(t, kp1, kp2)
+
+	other, err := NewAccount(PublicTest, &Hash{})
+	assert.Nil(t, err)
+	kp3, err := NewBLSKeyPairFromAccount(other)
+	assert.Nil(t, err)
+	assert.NotEqual(t, kp1.PublicKey, kp3.PublicKey)
+
+	_, err = NewBLSKeyPairFromAccount(nil)
+	assert.Equal(t, ErrNilAccount, err)
+}
+
+func TestBLSProofOfPossession(t *testing.T) {
+	kp := GenerateKeyPair(nil)
+	other := GenerateKeyPair(nil)
+
+	proof := kp.ProofOfPossession()
+	assert.True(t, kp.PublicKey.VerifyProofOfPossession(proof))
+	assert.False(t, other.PublicKey.VerifyProofOfPossession(proof))
+
+	// proof must not be accepted as an ordinary signature of public key
+	assert.False(t, kp.PublicKey.VerifyMessage(BLSMessage(kp.PublicKey), proof))
+}
+
+func TestBLSSerialization(t *testing.T) {
+	kp := GenerateKeyPair(nil)
+	sig := kp.SignMessage(BLSMessage("hello"))
+
+	sk, err := NewBLSPrivateKeyFromHex(kp.PrivateKey.HexString())
+	assert.Nil(t, err)
+	assert.Equal(t, kp.PrivateKey, sk)
+
+	pk, err := NewBLSPublicKeyFromHex(kp.PublicKey.HexString())
+	assert.Nil(t, err)
+	assert.Equal(t, kp.PublicKey, pk)
+
+	parsedSig, err := NewBLSSignatureFromHex(sig.HexString())
+	assert.Nil(t, err)
+	assert.Equal(t, sig, parsedSig)
+
+	_, err = NewBLSPublicKey(make([]byte, BLSPublicKeySize-1))
+	assert.Equal(t, ErrInvalidBLSPublicKey, err)
+	_, err = NewBLSSignature(make([]byte, BLSSignatureSize))
+	assert.Equal(t, ErrInvalidBLSSignature, err)
+
+	member := NewBLSCommitteeMember(kp)
+	b, err := json.Marshal(member)
+	assert.Nil(t, err)
+
+	parsedMember := &BLSCommitteeMember{}
+	assert.Nil(t, json.Unmarshal(b, parsedMember))
+	assert.Equal(t, member, parsedMember)
+}
+
+func TestBLSCommittee_VerifyAttestation(t *testing.T) {
+	hash := stringToHashPanic("AA2D2427E105A9B60DF634553849135DF629F1408A018D02B07A70CAFFB43093")
+	kps := []*KeyPair{GenerateKeyPair(nil), GenerateKeyPair(nil), GenerateKeyPair(nil)}
+
+	members := make([]*BLSCommitteeMember, len(kps))
+	signers := make([]BLSPublicKey, len(kps))
+	signatures := make([]BLSSignature, len(kps))
+	for i, kp := range kps {
+		members[i] = NewBLSCommitteeMember(kp)
+		signers[i] = kp.PublicKey
+		signatures[i] = kp.SignTransactionHash(hash)
+	}
+
+	committee, err := NewBLSCommittee(members...)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, committee.Size())
+
+	attestation, err := NewBLSAttestation(hash, signers, signatures)
+	assert.Nil(t, err)
+	assert.Nil(t, committee.VerifyAttestation(attestation))
+
+	outsider := GenerateKeyPair(nil)
+	attestation, err = NewBLSAttestation(hash,
+		[]BLSPublicKey{signers[0], outsider.PublicKey},
+		[]BLSSignature{signatures[0], outsider.SignTransactionHash(hash)},
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, ErrUnknownBLSCommitteeMember, committee.VerifyAttestation(attestation))
+
+	_, err = NewBLSCommittee(&BLSCommitteeMember{PublicKey: outsider.PublicKey, Proof: kps[0].ProofOfPossession()})
+	assert.Equal(t, ErrInvalidProofOfPossession, err)
+
+	_, err = NewBLSAttestation(hash, signers, []BLSSignature{signatures[1], signatures[0], signatures[2]})
+	assert.Equal(t, ErrInvalidBLSSignature, err)
+}
@@ -148,6 +148,11 @@ const (
 	Partial     TransactionGroup = "partial"
 )
 
+const (
+	successTransactionStatus      = "Success"
+	pastDeadlineTransactionStatus = "Failure_Core_Past_Deadline"
+)
+
 type NamespaceType uint8
 
 const (
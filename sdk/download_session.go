@@ -0,0 +1,231 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"context"
+	"time"
+)
+
+// DownloadSession is an open download of files from drive, identified by operation token.
+// Session is closed with EndFileDownloadTransaction on behalf of drive
+type DownloadSession struct {
+	storage      *StorageService
+	info         *DownloadInfo
+	pollInterval time.Duration
+	deadline     time.Duration
+}
+
+// OpenDownloadSession checks files and streaming budget of recipient, announces StartFileDownloadTransaction
+// and waits until node registers download
+func (s *StorageService) OpenDownloadSession(ctx context.Context, config *DownloadSessionConfig) (*DownloadSession, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkDownloadFiles(ctx, config); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkStreamingBudget(ctx, config.Recipient.PublicAccount.Address, DownloadBudget(config.Files)); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.client.NewStartFileDownloadTransaction(NewDeadline(config.Deadline), config.Drive, config.Files)
+	if err != nil {
+		return nil, err
+	}
+
+	tx.ToAggregate(config.Recipient.PublicAccount)
+
+	aggTx, err := s.client.NewCompleteAggregateTransaction(NewDeadline(config.Deadline), []Transaction{tx})
+	if err != nil {
+		return nil, err
+	}
+
+	// operation token is derived from aggregate and its inner transaction, so it's known before announcing
+	token, err := UniqueAggregateHash(aggTx, tx, s.client.GenerationHash())
+	if err != nil {
+		return nil, err
+	}
+
+	signed, err := config.Recipient.Sign(aggTx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.client.Transaction.announceAndWait(ctx, signed, config.PollInterval); err != nil {
+		return nil, err
+	}
+
+	session := &DownloadSession{
+		storage:      s,
+		pollInterval: config.PollInterval,
+		deadline:     config.Deadline,
+	}
+
+	err = poll(ctx, config.PollInterval, func() (bool, error) {
+		info, err := s.GetDownloadInfo(ctx, token)
+		if isNotFoundError(err) {
+			return false, nil
+		}
+
+		if err != nil {
+			return false, err
+		}
+
+		session.info = info
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// RecoverDownloadSessions returns open downloads of recipient, so sessions orphaned by stopped process can be closed.
+// When drive of config isn't nil, only downloads from it are returned
+func (s *StorageService) RecoverDownloadSessions(ctx context.Context, config *DownloadRecoveryConfig) ([]*DownloadSession, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	infos, err := s.GetAccountDownloadInfos(ctx, config.Recipient)
+	if isNotFoundError(err) {
+		return []*DownloadSession{}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]*DownloadSession, 0, len(infos))
+	for _, info := range infos {
+		if config.Drive != nil && info.DriveAccount.PublicKey != config.Drive.PublicKey {
+			continue
+		}
+
+		sessions = append(sessions, &DownloadSession{
+			storage:      s,
+			info:         info,
+			pollInterval: config.PollInterval,
+			deadline:     config.Deadline,
+		})
+	}
+
+	return sessions, nil
+}
+
+// checkDownloadFiles checks that every file is stored on drive with the same size
+func (s *StorageService) checkDownloadFiles(ctx context.Context, config *DownloadSessionConfig) error {
+	drive, err := s.GetDrive(ctx, config.Drive)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range config.Files {
+		if size, ok := drive.Files[*f.FileHash]; !ok || size != f.FileSize {
+			return ErrDownloadFileNotFound
+		}
+	}
+
+	return nil
+}
+
+func (s *StorageService) checkStreamingBudget(ctx context.Context, address *Address, budget Amount) error {
+	streamingId, err := s.client.Namespace.GetLinkedMosaicId(ctx, StreamingNamespaceId)
+	if err != nil {
+		return err
+	}
+
+	info, err := s.client.Account.GetAccountInfo(ctx, address)
+	if isNotFoundError(err) {
+		return ErrInsufficientStreamingBudget
+	}
+
+	if err != nil {
+		return err
+	}
+
+	for _, m := range info.Mosaics {
+		if m.AssetId.Id() == streamingId.Id() && m.Amount >= budget {
+			return nil
+		}
+	}
+
+	return ErrInsufficientStreamingBudget
+}
+
+// OperationToken returns token which identifies download on node
+func (d *DownloadSession) OperationToken() *Hash {
+	return d.info.OperationToken
+}
+
+// Info returns the last known state of download
+func (d *DownloadSession) Info() *DownloadInfo {
+	return d.info
+}
+
+// Budget returns streaming units charged for files of download
+func (d *DownloadSession) Budget() Amount {
+	return DownloadBudget(d.info.Files)
+}
+
+// Refresh requests state of download by its operation token
+func (d *DownloadSession) Refresh(ctx context.Context) (*DownloadInfo, error) {
+	info, err := d.storage.GetDownloadInfo(ctx, d.info.OperationToken)
+	if isNotFoundError(err) {
+		return nil, ErrDownloadNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	d.info = info
+
+	return info, nil
+}
+
+// Close announces EndFileDownloadTransaction for the remaining files of download and waits until node removes download.
+// Transaction is issued by drive, so it's wrapped into aggregate signed by replicator of drive
+func (d *DownloadSession) Close(ctx context.Context, replicator *Account) error {
+	if replicator == nil {
+		return ErrNilAccount
+	}
+
+	client := d.storage.client
+
+	tx, err := client.NewEndFileDownloadTransaction(NewDeadline(d.deadline), d.info.FileRecipient, d.info.OperationToken, d.info.Files)
+	if err != nil {
+		return err
+	}
+
+	tx.ToAggregate(d.info.DriveAccount)
+
+	aggTx, err := client.NewCompleteAggregateTransaction(NewDeadline(d.deadline), []Transaction{tx})
+	if err != nil {
+		return err
+	}
+
+	signed, err := replicator.Sign(aggTx)
+	if err != nil {
+		return err
+	}
+
+	if err := client.Transaction.announceAndWait(ctx, signed, d.pollInterval); err != nil {
+		return err
+	}
+
+	return poll(ctx, d.pollInterval, func() (bool, error) {
+		_, err := d.storage.GetDownloadInfo(ctx, d.info.OperationToken)
+		if isNotFoundError(err) {
+			return true, nil
+		}
+
+		return false, err
+	})
+}
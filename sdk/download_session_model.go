@@ -0,0 +1,82 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"time"
+)
+
+// DownloadSessionConfig is a configuration of download of files from drive
+type DownloadSessionConfig struct {
+	// Recipient signs StartFileDownloadTransaction and pays streaming units for files
+	Recipient *Account
+	Drive     *PublicAccount
+	Files     []*DownloadFile
+	// MaxFileSize limits size of every file, zero means no limit
+	MaxFileSize  StorageSize
+	PollInterval time.Duration
+	Deadline     time.Duration
+}
+
+func (c *DownloadSessionConfig) validate() error {
+	if c == nil || c.Recipient == nil || c.Drive == nil || len(c.Files) == 0 {
+		return ErrInvalidDownloadConfig
+	}
+
+	for _, f := range c.Files {
+		if f == nil || f.FileHash == nil {
+			return ErrInvalidDownloadConfig
+		}
+
+		if c.MaxFileSize > 0 && f.FileSize > c.MaxFileSize {
+			return ErrDownloadFileTooLarge
+		}
+	}
+
+	if c.PollInterval <= 0 {
+		c.PollInterval = time.Second
+	}
+
+	if c.Deadline <= 0 {
+		c.Deadline = time.Hour
+	}
+
+	return nil
+}
+
+// DownloadRecoveryConfig is a configuration of sessions recovered by StorageService.RecoverDownloadSessions
+type DownloadRecoveryConfig struct {
+	Recipient *PublicAccount
+	// Drive limits recovered sessions to downloads from drive when it isn't nil
+	Drive        *PublicAccount
+	PollInterval time.Duration
+	Deadline     time.Duration
+}
+
+func (c *DownloadRecoveryConfig) validate() error {
+	if c == nil || c.Recipient == nil {
+		return ErrInvalidDownloadConfig
+	}
+
+	if c.PollInterval <= 0 {
+		c.PollInterval = time.Second
+	}
+
+	if c.Deadline <= 0 {
+		c.Deadline = time.Hour
+	}
+
+	return nil
+}
+
+// DownloadBudget returns streaming units which are charged for download of files, one unit per byte
+func DownloadBudget(files []*DownloadFile) Amount {
+	var budget Amount
+	for _, f := range files {
+		budget += Amount(f.FileSize)
+	}
+
+	return budget
+}
@@ -0,0 +1,180 @@
+package sdk
+
+import (
+	"encoding/base32"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testDownloadChain simulates downloads on node, the first announced transaction opens download and the second one closes it
+type testDownloadChain struct {
+	sync.Mutex
+	recipient *Account
+	announced int
+	token     *Hash
+}
+
+func (c *testDownloadChain) infoJson(token *Hash) string {
+	return fmt.Sprintf(`{"downloadInfo": {
+		"operationToken": "%s", "driveKey": "%s", "fileRecipient": "%s", "height": [10, 0],
+		"files": [{"fileHash": "%s", "fileSize": [50, 0]}]
+	}}`, token, testDriveAccount.PublicKey, c.recipient.PublicAccount.PublicKey, testFileHash)
+}
+
+func (c *testDownloadChain) register(t *testing.T, m *sdkMock, balance int) {
+	raw, _ := base32.StdEncoding.DecodeString(c.recipient.PublicAccount.Address.Address)
+
+	m.AddHandler(fmt.Sprintf(driveRoute, testDriveAccount.PublicKey), func(resp http.ResponseWriter, req *http.Request) {
+		fmt.Fprint(resp, testDriveInfoJson)
+	})
+	m.AddHandler(fmt.Sprintf(namespaceRoute, StreamingNamespaceId.toHexString()), func(resp http.ResponseWriter, req *http.Request) {
+		fmt.Fprint(resp, tplInfo)
+	})
+	m.AddHandler(fmt.Sprintf(accountRoute, c.recipient.PublicAccount.Address.Address), func(resp http.ResponseWriter, req *http.Request) {
+		fmt.Fprintf(resp, `{"meta": {}, "account": {
+			"address": "%s", "addressHeight": [1, 0], "publicKey": "%s", "publicKeyHeight": [1, 0], "accountType": 0,
+			"linkedAccountKey": "0000000000000000000000000000000000000000000000000000000000000000",
+			"mosaics": [{"id": [1382215848, 1583663204], "amount": [%d, 0]}]
+		}}`, strings.ToUpper(hex.EncodeToString(raw)), c.recipient.PublicAccount.PublicKey, balance)
+	})
+
+	m.AddHandler(transactionsRoute, func(resp http.ResponseWriter, req *http.Request) {
+		c.Lock()
+		defer c.Unlock()
+
+		c.announced++
+
+		resp.WriteHeader(http.StatusAccepted)
+		fmt.Fprint(resp, `{"message": "packet 9 was pushed to the network via /transaction"}`)
+	})
+	m.AddHandler(strings.TrimSuffix(transactionStatusByIdRoute, "%s"), func(resp http.ResponseWriter, req *http.Request) {
+		hash := req.URL.Path[strings.LastIndex(req.URL.Path, "/")+1:]
+		fmt.Fprintf(resp, `{"group": "confirmed", "status": "Success", "hash": "%s", "deadline": [1, 0], "height": [1, 0]}`, hash)
+	})
+
+	// download of another drive is always open
+	m.AddHandler(fmt.Sprintf(accountDownloadInfosRoute, c.recipient.PublicAccount.PublicKey), func(resp http.ResponseWriter, req *http.Request) {
+		c.Lock()
+		defer c.Unlock()
+
+		infos := []string{strings.Replace(c.infoJson(&Hash{1}), testDriveAccount.PublicKey, testDriveOwnerAccount.PublicKey, 1)}
+		if c.announced == 1 {
+			infos = append(infos, c.infoJson(c.token))
+		}
+
+		fmt.Fprintf(resp, "[%s]", strings.Join(infos, ", "))
+	})
+	// download is registered by token requested after start of download
+	m.AddHandler(strings.TrimSuffix(downloadInfoRoute, "%s"), func(resp http.ResponseWriter, req *http.Request) {
+		c.Lock()
+		defer c.Unlock()
+
+		if c.announced != 1 {
+			resp.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		token, err := StringToHash(req.URL.Path[strings.LastIndex(req.URL.Path, "/")+1:])
+		assert.Nil(t, err)
+
+		if c.token == nil {
+			c.token = token
+		}
+
+		assert.Equal(t, c.token, token)
+		fmt.Fprint(resp, c.infoJson(token))
+	})
+}
+
+func newTestDownloadConfig(t *testing.T) *DownloadSessionConfig {
+	recipient, err := NewAccount(PublicTest, &Hash{})
+	assert.Nil(t, err)
+
+	return &DownloadSessionConfig{
+		Recipient:    recipient,
+		Drive:        testDriveAccount,
+		Files:        []*DownloadFile{{FileHash: testFileHash, FileSize: 50}},
+		PollInterval: time.Millisecond,
+	}
+}
+
+func TestStorageService_OpenDownloadSession(t *testing.T) {
+	mock := newSdkMock(0)
+	defer mock.Close()
+
+	config := newTestDownloadConfig(t)
+	chain := &testDownloadChain{recipient: config.Recipient}
+	chain.register(t, mock, 100)
+
+	client := mock.getPublicTestClientUnsafe()
+	client.config.GenerationHash = &Hash{}
+
+	session, err := client.Storage.OpenDownloadSession(ctx, config)
+	assert.Nil(t, err)
+	assert.Equal(t, chain.token, session.OperationToken())
+	assert.Equal(t, Amount(50), session.Budget())
+
+	info, err := session.Refresh(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, testDriveAccount.PublicKey, info.DriveAccount.PublicKey)
+
+	sessions, err := client.Storage.RecoverDownloadSessions(ctx, &DownloadRecoveryConfig{Recipient: config.Recipient.PublicAccount})
+	assert.Nil(t, err)
+	assert.Len(t, sessions, 2)
+
+	sessions, err = client.Storage.RecoverDownloadSessions(ctx, &DownloadRecoveryConfig{
+		Recipient:    config.Recipient.PublicAccount,
+		Drive:        testDriveAccount,
+		PollInterval: time.Millisecond,
+		Deadline:     time.Minute,
+	})
+	assert.Nil(t, err)
+	assert.Len(t, sessions, 1)
+	assert.Equal(t, chain.token, sessions[0].OperationToken())
+	assert.Equal(t, time.Millisecond, sessions[0].pollInterval)
+	assert.Equal(t, time.Minute, sessions[0].deadline)
+
+	_, err = client.Storage.RecoverDownloadSessions(ctx, &DownloadRecoveryConfig{})
+	assert.Equal(t, ErrInvalidDownloadConfig, err)
+
+	assert.Nil(t, session.Close(ctx, config.Recipient))
+	assert.Equal(t, 2, chain.announced)
+
+	_, err = session.Refresh(ctx)
+	assert.Equal(t, ErrDownloadNotFound, err)
+}
+
+func TestStorageService_OpenDownloadSession_Limits(t *testing.T) {
+	mock := newSdkMock(0)
+	defer mock.Close()
+
+	config := newTestDownloadConfig(t)
+	chain := &testDownloadChain{recipient: config.Recipient}
+	chain.register(t, mock, 10)
+
+	client := mock.getPublicTestClientUnsafe()
+
+	_, err := client.Storage.OpenDownloadSession(ctx, &DownloadSessionConfig{Recipient: config.Recipient})
+	assert.Equal(t, ErrInvalidDownloadConfig, err)
+
+	config.MaxFileSize = 10
+	_, err = client.Storage.OpenDownloadSession(ctx, config)
+	assert.Equal(t, ErrDownloadFileTooLarge, err)
+
+	config.MaxFileSize = 0
+	config.Files[0].FileSize = 20
+	_, err = client.Storage.OpenDownloadSession(ctx, config)
+	assert.Equal(t, ErrDownloadFileNotFound, err)
+
+	config.Files[0].FileSize = 50
+	_, err = client.Storage.OpenDownloadSession(ctx, config)
+	assert.Equal(t, ErrInsufficientStreamingBudget, err)
+	assert.Equal(t, 0, chain.announced)
+}
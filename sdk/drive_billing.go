@@ -0,0 +1,116 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"context"
+)
+
+// GetDriveFundsForecast returns forecast of payments of drive with balance of storage units on drive account
+func (s *StorageService) GetDriveFundsForecast(ctx context.Context, driveKey *PublicAccount) (*DriveFundsForecast, error) {
+	drive, err := s.GetDrive(ctx, driveKey)
+	if err != nil {
+		return nil, err
+	}
+
+	forecasts, err := s.forecastDrivesFunds(ctx, []*Drive{drive})
+	if err != nil {
+		return nil, err
+	}
+
+	return forecasts[0], nil
+}
+
+// GetUnderfundedDrives returns forecasts of drives matching options which can't pay for the remaining billing periods.
+// Every page of drives is requested, finished drives are skipped
+func (s *StorageService) GetUnderfundedDrives(ctx context.Context, dpOpts *DrivesPageOptions) ([]*DriveFundsForecast, error) {
+	it := s.IterateDrives(ctx, dpOpts, nil)
+	defer it.Close()
+
+	drives := make([]*Drive, 0)
+	for it.Next() {
+		if d := it.Drive(); d.State != Finished {
+			drives = append(drives, d)
+		}
+	}
+
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	underfunded := make([]*DriveFundsForecast, 0)
+	if len(drives) == 0 {
+		return underfunded, nil
+	}
+
+	forecasts, err := s.forecastDrivesFunds(ctx, drives)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range forecasts {
+		if f.Underfunded {
+			underfunded = append(underfunded, f)
+		}
+	}
+
+	return underfunded, nil
+}
+
+func (s *StorageService) forecastDrivesFunds(ctx context.Context, drives []*Drive) ([]*DriveFundsForecast, error) {
+	storageId, err := s.client.Namespace.GetLinkedMosaicId(ctx, StorageNamespaceId)
+	if err != nil {
+		return nil, err
+	}
+
+	height, err := s.client.Blockchain.GetBlockchainHeight(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	blockTime, err := s.client.BlockGenerationTime(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	balances, err := s.driveBalances(ctx, drives, storageId)
+	if err != nil {
+		return nil, err
+	}
+
+	forecasts := make([]*DriveFundsForecast, len(drives))
+	for i, d := range drives {
+		forecasts[i], err = NewDriveFundsForecast(d, balances[d.DriveAccount.Address.Address], height, blockTime)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return forecasts, nil
+}
+
+// driveBalances returns balances of mosaic on drive accounts by address, accounts unknown to node have zero balance
+func (s *StorageService) driveBalances(ctx context.Context, drives []*Drive, mosaicId *MosaicId) (map[string]Amount, error) {
+	addresses := make([]*Address, len(drives))
+	for i, d := range drives {
+		addresses[i] = d.DriveAccount.Address
+	}
+
+	infos, err := s.client.Account.GetAccountsInfo(ctx, addresses...)
+	if err != nil && !isNotFoundError(err) {
+		return nil, err
+	}
+
+	balances := make(map[string]Amount, len(infos))
+	for _, info := range infos {
+		for _, m := range info.Mosaics {
+			if m.AssetId.Id() == mosaicId.Id() {
+				balances[info.Address.Address] = m.Amount
+			}
+		}
+	}
+
+	return balances, nil
+}
@@ -0,0 +1,192 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"time"
+
+	"github.com/proximax-storage/go-xpx-utils/str"
+)
+
+// DriveCostEstimate is a projected cost of drive in storage units.
+// Drive account pays BillingPrice at the end of every billing period, payment is shared by replicators
+type DriveCostEstimate struct {
+	Periods              uint64
+	PeriodCost           Amount
+	ReplicatorPeriodCost Amount
+	TotalCost            Amount
+}
+
+func (e *DriveCostEstimate) String() string {
+	return str.StructToString(
+		"DriveCostEstimate",
+		str.NewField("Periods", str.IntPattern, e.Periods),
+		str.NewField("PeriodCost", str.StringPattern, e.PeriodCost),
+		str.NewField("ReplicatorPeriodCost", str.StringPattern, e.ReplicatorPeriodCost),
+		str.NewField("TotalCost", str.StringPattern, e.TotalCost),
+	)
+}
+
+// EstimateDriveCost returns cost of drive with passed parameters of PrepareDriveTransaction,
+// ErrMosaicAmountOverflow is returned when total cost doesn't fit into Amount
+func EstimateDriveCost(duration Duration, billingPeriod Duration, billingPrice Amount, replicas uint16) (*DriveCostEstimate, error) {
+	if duration <= 0 || billingPeriod <= 0 || duration%billingPeriod != 0 || billingPrice < 0 || replicas == 0 {
+		return nil, ErrInvalidDriveBilling
+	}
+
+	periods := uint64(duration / billingPeriod)
+
+	total, err := mulAmount(billingPrice, int64(periods))
+	if err != nil {
+		return nil, err
+	}
+
+	return &DriveCostEstimate{
+		Periods:              periods,
+		PeriodCost:           billingPrice,
+		ReplicatorPeriodCost: billingPrice / Amount(replicas),
+		TotalCost:            total,
+	}, nil
+}
+
+// CostEstimate returns cost of drive prepared by transaction
+func (tx *PrepareDriveTransaction) CostEstimate() (*DriveCostEstimate, error) {
+	return EstimateDriveCost(tx.Duration, tx.BillingPeriod, tx.BillingPrice, tx.Replicas)
+}
+
+// CostEstimate returns cost of drive during its whole duration
+func (drive *Drive) CostEstimate() (*DriveCostEstimate, error) {
+	return EstimateDriveCost(drive.Duration, drive.BillingPeriod, drive.BillingPrice, drive.Replicas)
+}
+
+// DriveSpending summarises payments made by drive
+type DriveSpending struct {
+	PaidPeriods uint64
+	BillingPaid Amount
+	UploadPaid  Amount
+	// Received is an amount paid to every receiver by public key
+	Received    map[string]Amount
+	LastPayment Height
+}
+
+func (s *DriveSpending) TotalPaid() Amount {
+	return s.BillingPaid + s.UploadPaid
+}
+
+func (s *DriveSpending) String() string {
+	return str.StructToString(
+		"DriveSpending",
+		str.NewField("PaidPeriods", str.IntPattern, s.PaidPeriods),
+		str.NewField("BillingPaid", str.StringPattern, s.BillingPaid),
+		str.NewField("UploadPaid", str.StringPattern, s.UploadPaid),
+		str.NewField("Received", str.StringPattern, s.Received),
+		str.NewField("LastPayment", str.StringPattern, s.LastPayment),
+	)
+}
+
+// Spending returns payments from BillingHistory and UploadPayments of drive
+func (drive *Drive) Spending() *DriveSpending {
+	s := &DriveSpending{
+		PaidPeriods: uint64(len(drive.BillingHistory)),
+		Received:    make(map[string]Amount),
+	}
+
+	add := func(p *PaymentInformation) Amount {
+		if p.Receiver != nil {
+			s.Received[p.Receiver.PublicKey] += p.Amount
+		}
+
+		if p.Height > s.LastPayment {
+			s.LastPayment = p.Height
+		}
+
+		return p.Amount
+	}
+
+	for _, b := range drive.BillingHistory {
+		for _, p := range b.Payments {
+			s.BillingPaid += add(p)
+		}
+	}
+
+	for _, p := range drive.UploadPayments {
+		s.UploadPaid += add(p)
+	}
+
+	return s
+}
+
+// DriveFundsForecast predicts whether drive account can pay for the remaining billing periods
+type DriveFundsForecast struct {
+	Drive *Drive
+	// Balance of storage units on drive account
+	Balance          Amount
+	RemainingPeriods uint64
+	FundedPeriods    uint64
+	RemainingCost    Amount
+	Shortfall        Amount
+	Underfunded      bool
+	// RunOutHeight is a height of the first payment which drive account can't afford, it is zero when drive is funded
+	RunOutHeight Height
+	// RunOutIn is an estimated time until RunOutHeight
+	RunOutIn time.Duration
+}
+
+func (f *DriveFundsForecast) String() string {
+	return str.StructToString(
+		"DriveFundsForecast",
+		str.NewField("Drive", str.StringPattern, f.Drive.DriveAccount),
+		str.NewField("Balance", str.StringPattern, f.Balance),
+		str.NewField("RemainingPeriods", str.IntPattern, f.RemainingPeriods),
+		str.NewField("FundedPeriods", str.IntPattern, f.FundedPeriods),
+		str.NewField("RemainingCost", str.StringPattern, f.RemainingCost),
+		str.NewField("Shortfall", str.StringPattern, f.Shortfall),
+		str.NewField("Underfunded", str.BooleanPattern, f.Underfunded),
+		str.NewField("RunOutHeight", str.StringPattern, f.RunOutHeight),
+		str.NewField("RunOutIn", str.StringPattern, f.RunOutIn),
+	)
+}
+
+// NewDriveFundsForecast returns forecast for drive with passed balance of drive account at chain height.
+// blockTime is a block generation time used to estimate time until funds run out
+func NewDriveFundsForecast(drive *Drive, balance Amount, height Height, blockTime time.Duration) (*DriveFundsForecast, error) {
+	estimate, err := drive.CostEstimate()
+	if err != nil {
+		return nil, err
+	}
+
+	f := &DriveFundsForecast{
+		Drive:   drive,
+		Balance: balance,
+	}
+
+	paid := uint64(len(drive.BillingHistory))
+	if drive.State == Finished || paid >= estimate.Periods {
+		return f, nil
+	}
+
+	f.RemainingPeriods = estimate.Periods - paid
+	f.RemainingCost = drive.BillingPrice * Amount(f.RemainingPeriods)
+
+	f.FundedPeriods = f.RemainingPeriods
+	if drive.BillingPrice > 0 && uint64(balance/drive.BillingPrice) < f.RemainingPeriods {
+		f.FundedPeriods = uint64(balance / drive.BillingPrice)
+	}
+
+	if f.FundedPeriods == f.RemainingPeriods {
+		return f, nil
+	}
+
+	f.Underfunded = true
+	f.Shortfall = f.RemainingCost - balance
+
+	// payment of period is made at its end
+	f.RunOutHeight = drive.Start + Height(paid+f.FundedPeriods+1)*Height(drive.BillingPeriod)
+	if f.RunOutHeight > height {
+		f.RunOutIn = time.Duration(f.RunOutHeight-height) * blockTime
+	}
+
+	return f, nil
+}
@@ -0,0 +1,136 @@
+package sdk
+
+import (
+	"encoding/base32"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/proximax-storage/go-xpx-utils/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimateDriveCost(t *testing.T) {
+	estimate, err := EstimateDriveCost(30, 10, 50, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, &DriveCostEstimate{
+		Periods:              3,
+		PeriodCost:           50,
+		ReplicatorPeriodCost: 16,
+		TotalCost:            150,
+	}, estimate)
+
+	_, err = EstimateDriveCost(30, 7, 50, 3)
+	assert.Equal(t, ErrInvalidDriveBilling, err)
+
+	_, err = EstimateDriveCost(30, 0, 50, 3)
+	assert.Equal(t, ErrInvalidDriveBilling, err)
+
+	_, err = EstimateDriveCost(30, 10, 50, 0)
+	assert.Equal(t, ErrInvalidDriveBilling, err)
+
+	_, err = EstimateDriveCost(30, 10, math.MaxInt64/2, 3)
+	assert.Equal(t, ErrMosaicAmountOverflow, err)
+
+	tx, err := NewPrepareDriveTransaction(NewDeadline(time.Hour), testDriveOwnerAccount, 30, 10, 50, 1000, 3, 1, 100, PublicTest)
+	assert.Nil(t, err)
+
+	txEstimate, err := tx.CostEstimate()
+	assert.Nil(t, err)
+	assert.Equal(t, estimate, txEstimate)
+}
+
+func TestDrive_Spending(t *testing.T) {
+	spending := testDriveInfo.Spending()
+
+	assert.Equal(t, uint64(1), spending.PaidPeriods)
+	assert.Equal(t, Amount(10), spending.BillingPaid)
+	assert.Equal(t, Amount(9999925), spending.UploadPaid)
+	assert.Equal(t, Amount(9999935), spending.TotalPaid())
+	assert.Equal(t, Amount(10), spending.Received[testReplicatorAccount.PublicKey])
+	assert.Equal(t, Amount(9999925), spending.Received[testDriveOwnerAccount.PublicKey])
+	assert.Equal(t, Height(2098), spending.LastPayment)
+}
+
+func TestNewDriveFundsForecast(t *testing.T) {
+	drive := &Drive{
+		State:          InProgress,
+		Start:          100,
+		Duration:       40,
+		BillingPeriod:  10,
+		BillingPrice:   50,
+		Replicas:       1,
+		BillingHistory: []*BillingDescription{{Start: 100, End: 110}},
+	}
+
+	f, err := NewDriveFundsForecast(drive, 160, 115, time.Second)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(3), f.RemainingPeriods)
+	assert.Equal(t, Amount(150), f.RemainingCost)
+	assert.Equal(t, uint64(3), f.FundedPeriods)
+	assert.False(t, f.Underfunded)
+	assert.Equal(t, Height(0), f.RunOutHeight)
+
+	f, err = NewDriveFundsForecast(drive, 60, 115, time.Second)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1), f.FundedPeriods)
+	assert.True(t, f.Underfunded)
+	assert.Equal(t, Amount(90), f.Shortfall)
+	// the second period is paid at 120, the third one at 130
+	assert.Equal(t, Height(130), f.RunOutHeight)
+	assert.Equal(t, 15*time.Second, f.RunOutIn)
+
+	drive.State = Finished
+	f, err = NewDriveFundsForecast(drive, 0, 115, time.Second)
+	assert.Nil(t, err)
+	assert.False(t, f.Underfunded)
+	assert.Equal(t, uint64(0), f.RemainingPeriods)
+}
+
+func TestStorageService_GetUnderfundedDrives(t *testing.T) {
+	mockServer := newSdkMock(0)
+	defer mockServer.Close()
+
+	activeDriveJson := strings.Replace(testDriveInfoJson, `"state": 3`, `"state": 2`, 1)
+	raw, _ := base32.StdEncoding.DecodeString(testDriveAccount.Address.Address)
+
+	mockServer.AddRouter(&mock.Router{
+		Path:     drivesRoute,
+		RespBody: `{"data": [` + activeDriveJson + `, ` + testDriveInfoJson + `], "pagination": {"totalEntries": 2, "pageNumber": 1, "pageSize": 20, "totalPages": 1}}`,
+	})
+	mockServer.AddRouter(&mock.Router{
+		Path:     fmt.Sprintf(namespaceRoute, StorageNamespaceId.toHexString()),
+		RespBody: tplInfo,
+	})
+	mockServer.AddRouter(&mock.Router{
+		Path:     blockHeightRoute,
+		RespBody: `{"height": [2074, 0]}`,
+	})
+	mockServer.AddRouter(&mock.Router{
+		Path:     fmt.Sprintf(configRoute, Height(2074)),
+		RespBody: networkConfigJson,
+	})
+	mockServer.AddHandler(accountsRoute, func(resp http.ResponseWriter, req *http.Request) {
+		fmt.Fprintf(resp, `[{"meta": {}, "account": {
+			"address": "%s", "addressHeight": [1, 0], "publicKey": "%s", "publicKeyHeight": [1, 0], "accountType": 0,
+			"linkedAccountKey": "0000000000000000000000000000000000000000000000000000000000000000",
+			"mosaics": [{"id": [1382215848, 1583663204], "amount": [60, 0]}]
+		}}]`, strings.ToUpper(hex.EncodeToString(raw)), testDriveAccount.PublicKey)
+	})
+
+	forecasts, err := mockServer.getPublicTestClientUnsafe().Storage.GetUnderfundedDrives(ctx, nil)
+	assert.Nil(t, err)
+	assert.Len(t, forecasts, 1)
+
+	f := forecasts[0]
+	assert.Equal(t, Amount(60), f.Balance)
+	assert.Equal(t, uint64(2), f.RemainingPeriods)
+	assert.Equal(t, uint64(1), f.FundedPeriods)
+	assert.Equal(t, Amount(40), f.Shortfall)
+	assert.Equal(t, Height(2076), f.RunOutHeight)
+	assert.Equal(t, 30*time.Second, f.RunOutIn)
+}
@@ -0,0 +1,132 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"sort"
+
+	"github.com/proximax-storage/go-xpx-utils/str"
+)
+
+// DriveDiff is a difference between two snapshots of drive
+type DriveDiff struct {
+	DriveKey      string
+	PreviousState DriveState
+	State         DriveState
+	// Previous is nil when drive wasn't known before, Drive is nil when snapshot of drive isn't available
+	Previous *Drive
+	Drive    *Drive
+	// JoinedReplicators and LeftReplicators are keys of replicators, replicator leaves when it's removed or its end is set
+	JoinedReplicators []string
+	LeftReplicators   []string
+	AddedFiles        []*Action
+	RemovedFiles      []*Action
+	// NewBillingEntries are billing periods which are added to history of drive
+	NewBillingEntries []*BillingDescription
+}
+
+func (d *DriveDiff) String() string {
+	return str.StructToString(
+		"DriveDiff",
+		str.NewField("DriveKey", str.StringPattern, d.DriveKey),
+		str.NewField("PreviousState", str.IntPattern, d.PreviousState),
+		str.NewField("State", str.IntPattern, d.State),
+		str.NewField("JoinedReplicators", str.StringPattern, d.JoinedReplicators),
+		str.NewField("LeftReplicators", str.StringPattern, d.LeftReplicators),
+		str.NewField("AddedFiles", str.StringPattern, d.AddedFiles),
+		str.NewField("RemovedFiles", str.StringPattern, d.RemovedFiles),
+		str.NewField("NewBillingEntries", str.StringPattern, d.NewBillingEntries),
+	)
+}
+
+// IsEmpty returns true when drive has the same state, replicators, files and billing history
+func (d *DriveDiff) IsEmpty() bool {
+	return d.PreviousState == d.State &&
+		len(d.JoinedReplicators) == 0 &&
+		len(d.LeftReplicators) == 0 &&
+		len(d.AddedFiles) == 0 &&
+		len(d.RemovedFiles) == 0 &&
+		len(d.NewBillingEntries) == 0
+}
+
+// DiffDrives returns difference between snapshots of the same drive, any of snapshots can be nil.
+// Everything of current snapshot is new when previous one is nil
+func DiffDrives(previous, current *Drive) *DriveDiff {
+	diff := &DriveDiff{
+		Previous:          previous,
+		Drive:             current,
+		PreviousState:     NotStarted,
+		JoinedReplicators: make([]string, 0),
+		LeftReplicators:   make([]string, 0),
+		AddedFiles:        make([]*Action, 0),
+		RemovedFiles:      make([]*Action, 0),
+		NewBillingEntries: make([]*BillingDescription, 0),
+	}
+
+	if previous == nil {
+		previous = &Drive{}
+	} else {
+		diff.PreviousState = previous.State
+		diff.State = previous.State
+	}
+
+	if previous.DriveAccount != nil {
+		diff.DriveKey = previous.DriveAccount.PublicKey
+	}
+
+	if current == nil {
+		return diff
+	}
+
+	diff.State = current.State
+	if current.DriveAccount != nil {
+		diff.DriveKey = current.DriveAccount.PublicKey
+	}
+
+	for key, info := range current.Replicators {
+		if info.End != 0 {
+			continue
+		}
+
+		if prev, ok := previous.Replicators[key]; !ok || prev.End != 0 {
+			diff.JoinedReplicators = append(diff.JoinedReplicators, key)
+		}
+	}
+
+	for key, prev := range previous.Replicators {
+		if prev.End != 0 {
+			continue
+		}
+
+		if info, ok := current.Replicators[key]; !ok || info.End != 0 {
+			diff.LeftReplicators = append(diff.LeftReplicators, key)
+		}
+	}
+
+	sort.Strings(diff.JoinedReplicators)
+	sort.Strings(diff.LeftReplicators)
+
+	for hash, size := range current.Files {
+		if _, ok := previous.Files[hash]; !ok {
+			diff.AddedFiles = append(diff.AddedFiles, &Action{FileHash: hashPtr(hash), FileSize: size})
+		}
+	}
+
+	for hash, size := range previous.Files {
+		if _, ok := current.Files[hash]; !ok {
+			diff.RemovedFiles = append(diff.RemovedFiles, &Action{FileHash: hashPtr(hash), FileSize: size})
+		}
+	}
+
+	sortActions(diff.AddedFiles)
+	sortActions(diff.RemovedFiles)
+
+	// billing history only grows, so entries after previous ones are new
+	if len(current.BillingHistory) > len(previous.BillingHistory) {
+		diff.NewBillingEntries = append(diff.NewBillingEntries, current.BillingHistory[len(previous.BillingHistory):]...)
+	}
+
+	return diff
+}
@@ -0,0 +1,53 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffDrives(t *testing.T) {
+	previous := &Drive{
+		DriveAccount: testDriveAccount,
+		State:        Pending,
+		Replicators: map[string]*ReplicatorInfo{
+			testDriveOwnerAccount.PublicKey: {Account: testDriveOwnerAccount, Start: 1},
+		},
+		Files:          map[Hash]StorageSize{{1}: 10},
+		BillingHistory: []*BillingDescription{{Start: 1, End: 2}},
+	}
+
+	current := &Drive{
+		DriveAccount: testDriveAccount,
+		State:        InProgress,
+		Replicators: map[string]*ReplicatorInfo{
+			testDriveOwnerAccount.PublicKey: {Account: testDriveOwnerAccount, Start: 1, End: 3},
+			testReplicatorAccount.PublicKey: {Account: testReplicatorAccount, Start: 3},
+		},
+		Files:          map[Hash]StorageSize{{2}: 20},
+		BillingHistory: []*BillingDescription{{Start: 1, End: 2}, {Start: 2, End: 3}},
+	}
+
+	diff := DiffDrives(previous, current)
+	assert.Equal(t, testDriveAccount.PublicKey, diff.DriveKey)
+	assert.Equal(t, Pending, diff.PreviousState)
+	assert.Equal(t, InProgress, diff.State)
+	assert.Equal(t, []string{testReplicatorAccount.PublicKey}, diff.JoinedReplicators)
+	assert.Equal(t, []string{testDriveOwnerAccount.PublicKey}, diff.LeftReplicators)
+	assert.Equal(t, []*Action{{FileHash: &Hash{2}, FileSize: 20}}, diff.AddedFiles)
+	assert.Equal(t, []*Action{{FileHash: &Hash{1}, FileSize: 10}}, diff.RemovedFiles)
+	assert.Equal(t, []*BillingDescription{{Start: 2, End: 3}}, diff.NewBillingEntries)
+	assert.False(t, diff.IsEmpty())
+
+	assert.True(t, DiffDrives(current, current).IsEmpty())
+
+	diff = DiffDrives(nil, previous)
+	assert.Equal(t, NotStarted, diff.PreviousState)
+	assert.Equal(t, []string{testDriveOwnerAccount.PublicKey}, diff.JoinedReplicators)
+	assert.Len(t, diff.AddedFiles, 1)
+	assert.Len(t, diff.NewBillingEntries, 1)
+
+	diff = DiffDrives(previous, nil)
+	assert.Equal(t, Pending, diff.State)
+	assert.True(t, diff.IsEmpty())
+}
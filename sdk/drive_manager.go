@@ -0,0 +1,477 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// DriveManager runs lifecycle of drive from PrepareDriveTransaction to EndDriveTransaction.
+// Every step is persisted in DriveProgressStore before and after announcing its transactions,
+// so Run continues from the last step after restart of process
+type DriveManager struct {
+	client *Client
+	config *DriveManagerConfig
+	store  DriveProgressStore
+
+	handlersLock sync.RWMutex
+	handlers     []DriveEventHandler
+}
+
+func NewDriveManager(client *Client, config *DriveManagerConfig, store DriveProgressStore) (*DriveManager, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	if store == nil {
+		store = NewMemoryDriveProgressStore()
+	}
+
+	return &DriveManager{
+		client: client,
+		config: config,
+		store:  store,
+	}, nil
+}
+
+// AddHandlers adds handlers which are called on every change of drive state
+func (m *DriveManager) AddHandlers(handlers ...DriveEventHandler) {
+	m.handlersLock.Lock()
+	defer m.handlersLock.Unlock()
+
+	m.handlers = append(m.handlers, handlers...)
+}
+
+// Progress returns persisted progress of drive
+func (m *DriveManager) Progress() (*DriveProgress, error) {
+	progress, err := m.store.LoadDriveProgress(m.config.Drive.PublicAccount.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if progress == nil {
+		progress = &DriveProgress{
+			DriveKey: m.config.Drive.PublicAccount.PublicKey,
+			Step:     DrivePrepareStep,
+			State:    NotStarted,
+		}
+	}
+
+	return progress, nil
+}
+
+// Run executes remaining steps of drive lifecycle and returns finished drive
+func (m *DriveManager) Run(ctx context.Context) (*Drive, error) {
+	progress, err := m.Progress()
+	if err != nil {
+		return nil, err
+	}
+
+	for progress.Step < DriveCompletedStep {
+		if err := m.runStep(ctx, progress); err != nil {
+			return nil, err
+		}
+
+		progress.Step++
+		progress.Announced = nil
+
+		if err := m.store.SaveDriveProgress(progress); err != nil {
+			return nil, err
+		}
+	}
+
+	return m.client.Storage.GetDrive(ctx, m.config.Drive.PublicAccount)
+}
+
+func (m *DriveManager) runStep(ctx context.Context, progress *DriveProgress) error {
+	switch progress.Step {
+	case DrivePrepareStep:
+		if err := m.announce(ctx, progress, m.prepareDrive); err != nil {
+			return err
+		}
+
+		return m.waitDrive(ctx, progress, func(d *Drive) bool { return d.State >= Pending })
+	case DriveJoinStep:
+		if err := m.announce(ctx, progress, m.joinToDrive); err != nil {
+			return err
+		}
+
+		return m.waitDrive(ctx, progress, func(d *Drive) bool { return d.State >= InProgress })
+	case DriveFileSystemStep:
+		if len(m.config.Files) == 0 {
+			return nil
+		}
+
+		if err := m.announce(ctx, progress, m.uploadFiles); err != nil {
+			return err
+		}
+
+		return m.waitDrive(ctx, progress, m.hasFiles)
+	case DriveFilesDepositStep:
+		if len(m.config.Files) == 0 {
+			return nil
+		}
+
+		if err := m.announce(ctx, progress, m.depositFiles); err != nil {
+			return err
+		}
+
+		return m.waitDrive(ctx, progress, m.isDeposited)
+	case DriveStartVerificationStep:
+		if !m.config.Verify {
+			return nil
+		}
+
+		return m.announce(ctx, progress, m.startVerification)
+	case DriveEndVerificationStep:
+		if !m.config.Verify {
+			return nil
+		}
+
+		// results of verification are announced by replicators from their checks of drive
+		return m.waitVerification(ctx)
+	case DriveEndStep:
+		if err := m.announce(ctx, progress, m.endDrive); err != nil {
+			return err
+		}
+
+		return m.waitDrive(ctx, progress, func(d *Drive) bool { return d.State == Finished })
+	}
+
+	return nil
+}
+
+// announce signs transactions of step once, persists them and waits for their confirmation.
+// Transactions persisted before crash are checked on node first: confirmed ones are kept, expired ones
+// are signed again with a new deadline and the rest are announced again, node ignores duplicates
+func (m *DriveManager) announce(ctx context.Context, progress *DriveProgress, build func(context.Context) ([]*SignedTransaction, error)) error {
+	for {
+		pending, err := m.pendingAnnounced(ctx, progress, build)
+		if err != nil {
+			return err
+		}
+
+		expired, err := m.waitAnnounced(ctx, progress, pending)
+		if err != nil || !expired {
+			return err
+		}
+	}
+}
+
+// pendingAnnounced returns persisted transactions of step which are not confirmed yet.
+// Expired transactions are replaced by transactions of the same position in new build
+func (m *DriveManager) pendingAnnounced(ctx context.Context, progress *DriveProgress, build func(context.Context) ([]*SignedTransaction, error)) ([]*SignedTransaction, error) {
+	if len(progress.Announced) == 0 {
+		return m.rebuildAnnounced(ctx, progress, build, nil)
+	}
+
+	confirmed := make([]bool, len(progress.Announced))
+	expired := false
+
+	for i, tx := range progress.Announced {
+		status, err := m.client.Transaction.GetTransactionStatus(ctx, tx.Hash.String())
+		if err != nil && !isNotFoundError(err) {
+			return nil, err
+		}
+
+		switch {
+		case err == nil && status.Status == pastDeadlineTransactionStatus:
+			expired = true
+		case err == nil && status.Status != successTransactionStatus:
+			return nil, errors.Wrapf(ErrTransactionFailed, "%s: %s", tx.Hash, status.Status)
+		case err == nil && status.Group == Confirmed:
+			confirmed[i] = true
+		case !time.Now().Before(progress.Expires):
+			expired = true
+		}
+	}
+
+	if expired {
+		return m.rebuildAnnounced(ctx, progress, build, confirmed)
+	}
+
+	pending := make([]*SignedTransaction, 0, len(progress.Announced))
+	for i, tx := range progress.Announced {
+		if !confirmed[i] {
+			pending = append(pending, tx)
+		}
+	}
+
+	return pending, nil
+}
+
+// rebuildAnnounced signs transactions of step again keeping confirmed ones and persists them with their expiration time
+func (m *DriveManager) rebuildAnnounced(ctx context.Context, progress *DriveProgress, build func(context.Context) ([]*SignedTransaction, error), confirmed []bool) ([]*SignedTransaction, error) {
+	signed, err := build(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// deadlines of transactions are earlier than expiration time taken after their signing
+	expires := time.Now().Add(m.config.Deadline)
+
+	pending := make([]*SignedTransaction, 0, len(signed))
+	for i, tx := range signed {
+		if i < len(confirmed) && confirmed[i] {
+			signed[i] = progress.Announced[i]
+			continue
+		}
+
+		pending = append(pending, tx)
+	}
+
+	progress.Announced = signed
+	progress.Expires = expires
+	if err := m.store.SaveDriveProgress(progress); err != nil {
+		return nil, err
+	}
+
+	return pending, nil
+}
+
+// waitAnnounced announces transactions and waits for their confirmation until they expire.
+// It returns true when transactions are expired before confirmation
+func (m *DriveManager) waitAnnounced(ctx context.Context, progress *DriveProgress, pending []*SignedTransaction) (bool, error) {
+	for _, tx := range pending {
+		if _, err := m.client.Transaction.Announce(ctx, tx); err != nil {
+			return false, err
+		}
+	}
+
+	waitCtx, cancel := context.WithDeadline(ctx, progress.Expires)
+	defer cancel()
+
+	for _, tx := range pending {
+		err := m.client.Transaction.waitForConfirmation(waitCtx, tx.Hash, m.config.PollInterval)
+		if err != nil && ctx.Err() == nil && waitCtx.Err() == context.DeadlineExceeded {
+			return true, nil
+		}
+
+		// node rejects transaction with past deadline when clocks of node and process differ
+		if errors.Cause(err) == ErrTransactionFailed && strings.HasSuffix(err.Error(), pastDeadlineTransactionStatus) {
+			return true, nil
+		}
+
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return false, nil
+}
+
+// waitDrive polls drive until condition is met and emits event on every change of its state
+func (m *DriveManager) waitDrive(ctx context.Context, progress *DriveProgress, cond func(*Drive) bool) error {
+	return poll(ctx, m.config.PollInterval, func() (bool, error) {
+		drive, err := m.client.Storage.GetDrive(ctx, m.config.Drive.PublicAccount)
+		if isNotFoundError(err) {
+			return false, nil
+		}
+
+		if err != nil {
+			return false, err
+		}
+
+		if drive.State != progress.State {
+			event := &DriveEvent{
+				Drive:         drive,
+				PreviousState: progress.State,
+				State:         drive.State,
+				Step:          progress.Step,
+			}
+
+			progress.State = drive.State
+			if err := m.store.SaveDriveProgress(progress); err != nil {
+				return false, err
+			}
+
+			m.emit(event)
+		}
+
+		return cond(drive), nil
+	})
+}
+
+func (m *DriveManager) waitVerification(ctx context.Context) error {
+	return poll(ctx, m.config.PollInterval, func() (bool, error) {
+		status, err := m.client.Storage.GetVerificationStatus(ctx, m.config.Drive.PublicAccount)
+		if err != nil {
+			return false, err
+		}
+
+		return !status.Active, nil
+	})
+}
+
+func (m *DriveManager) emit(event *DriveEvent) {
+	m.handlersLock.RLock()
+	defer m.handlersLock.RUnlock()
+
+	for _, h := range m.handlers {
+		h(event)
+	}
+}
+
+func (m *DriveManager) hasFiles(drive *Drive) bool {
+	for _, f := range m.config.Files {
+		if _, ok := drive.Files[*f.FileHash]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (m *DriveManager) isDeposited(drive *Drive) bool {
+	for _, r := range drive.Replicators {
+		for _, f := range m.config.Files {
+			if r.ActiveFilesWithoutDeposit[*f.FileHash] {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func (m *DriveManager) deadline() *Deadline {
+	return NewDeadline(m.config.Deadline)
+}
+
+// prepareDrive returns aggregate of PrepareDriveTransaction signed by owner and cosigned by drive account
+func (m *DriveManager) prepareDrive(ctx context.Context) ([]*SignedTransaction, error) {
+	c := m.config
+
+	prepareTx, err := m.client.NewPrepareDriveTransaction(m.deadline(), c.Owner.PublicAccount, c.Duration, c.BillingPeriod,
+		c.BillingPrice, c.DriveSize, c.Replicas, c.MinReplicators, c.PercentApprovers)
+	if err != nil {
+		return nil, err
+	}
+
+	prepareTx.ToAggregate(c.Drive.PublicAccount)
+
+	aggTx, err := m.client.NewCompleteAggregateTransaction(m.deadline(), []Transaction{prepareTx})
+	if err != nil {
+		return nil, err
+	}
+
+	signed, err := c.Owner.SignWithCosignatures(aggTx, []*Account{c.Drive})
+	if err != nil {
+		return nil, err
+	}
+
+	return []*SignedTransaction{signed}, nil
+}
+
+func (m *DriveManager) joinToDrive(ctx context.Context) ([]*SignedTransaction, error) {
+	return m.signByReplicators(func() (Transaction, error) {
+		return m.client.NewJoinToDriveTransaction(m.deadline(), m.config.Drive.PublicAccount)
+	})
+}
+
+func (m *DriveManager) uploadFiles(ctx context.Context) ([]*SignedTransaction, error) {
+	c := m.config
+
+	drive, err := m.client.Storage.GetDrive(ctx, c.Drive.PublicAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	fsTx, err := m.client.NewDriveFileSystemTransaction(m.deadline(), c.Drive.PublicAccount.PublicKey, c.RootHash, drive.RootHash, c.Files, []*Action{})
+	if err != nil {
+		return nil, err
+	}
+
+	fsTx.ToAggregate(c.Owner.PublicAccount)
+
+	aggTx, err := m.client.NewCompleteAggregateTransaction(m.deadline(), []Transaction{fsTx})
+	if err != nil {
+		return nil, err
+	}
+
+	signed, err := c.Owner.Sign(aggTx)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*SignedTransaction{signed}, nil
+}
+
+func (m *DriveManager) depositFiles(ctx context.Context) ([]*SignedTransaction, error) {
+	files := make([]*File, len(m.config.Files))
+	for i, f := range m.config.Files {
+		files[i] = &File{FileHash: f.FileHash}
+	}
+
+	return m.signByReplicators(func() (Transaction, error) {
+		return m.client.NewFilesDepositTransaction(m.deadline(), m.config.Drive.PublicAccount, files)
+	})
+}
+
+func (m *DriveManager) startVerification(ctx context.Context) ([]*SignedTransaction, error) {
+	tx, err := m.client.NewStartDriveVerificationTransaction(m.deadline(), m.config.Drive.PublicAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	signed, err := m.config.Owner.Sign(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*SignedTransaction{signed}, nil
+}
+
+// endDrive returns aggregate of EndDriveTransaction on behalf of drive account signed by the first replicator,
+// drive ending by external replicators is awaited
+func (m *DriveManager) endDrive(ctx context.Context) ([]*SignedTransaction, error) {
+	if len(m.config.Replicators) == 0 {
+		return nil, nil
+	}
+
+	endTx, err := m.client.NewEndDriveTransaction(m.deadline(), m.config.Drive.PublicAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	endTx.ToAggregate(m.config.Drive.PublicAccount)
+
+	aggTx, err := m.client.NewCompleteAggregateTransaction(m.deadline(), []Transaction{endTx})
+	if err != nil {
+		return nil, err
+	}
+
+	signed, err := m.config.Replicators[0].Sign(aggTx)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*SignedTransaction{signed}, nil
+}
+
+func (m *DriveManager) signByReplicators(build func() (Transaction, error)) ([]*SignedTransaction, error) {
+	signed := make([]*SignedTransaction, 0, len(m.config.Replicators))
+
+	for _, r := range m.config.Replicators {
+		tx, err := build()
+		if err != nil {
+			return nil, err
+		}
+
+		stx, err := r.Sign(tx)
+		if err != nil {
+			return nil, err
+		}
+
+		signed = append(signed, stx)
+	}
+
+	return signed, nil
+}
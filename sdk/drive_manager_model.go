@@ -0,0 +1,228 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/proximax-storage/go-xpx-utils/str"
+)
+
+// DriveStep is a step of drive lifecycle executed by DriveManager
+type DriveStep uint8
+
+const (
+	DrivePrepareStep DriveStep = iota
+	DriveJoinStep
+	DriveFileSystemStep
+	DriveFilesDepositStep
+	DriveStartVerificationStep
+	// DriveEndVerificationStep doesn't announce anything, it waits until replicators end verification
+	DriveEndVerificationStep
+	DriveEndStep
+	DriveCompletedStep
+)
+
+func (s DriveStep) String() string {
+	switch s {
+	case DrivePrepareStep:
+		return "Prepare"
+	case DriveJoinStep:
+		return "Join"
+	case DriveFileSystemStep:
+		return "FileSystem"
+	case DriveFilesDepositStep:
+		return "FilesDeposit"
+	case DriveStartVerificationStep:
+		return "StartVerification"
+	case DriveEndVerificationStep:
+		return "EndVerification"
+	case DriveEndStep:
+		return "End"
+	case DriveCompletedStep:
+		return "Completed"
+	}
+
+	return fmt.Sprintf("%d", s)
+}
+
+// DriveManagerConfig describes drive created and run by DriveManager
+type DriveManagerConfig struct {
+	Owner *Account
+	Drive *Account
+	// Replicators are signed by manager, when they are empty manager waits for replicators joining by themselves
+	Replicators      []*Account
+	Duration         Duration
+	BillingPeriod    Duration
+	BillingPrice     Amount
+	DriveSize        StorageSize
+	Replicas         uint16
+	MinReplicators   uint16
+	PercentApprovers uint8
+	// Files are added to drive after it is started, RootHash is a new root hash of drive with the files
+	Files    []*Action
+	RootHash *Hash
+	// Verify starts verification of drive before it is finished and waits until replicators end it
+	Verify bool
+	// PollInterval is an interval of polling drive and transactions statuses, one second by default
+	PollInterval time.Duration
+	// Deadline of announced transactions, one hour by default
+	Deadline time.Duration
+}
+
+func (c *DriveManagerConfig) validate() error {
+	if c == nil || c.Owner == nil || c.Drive == nil || c.DriveSize == 0 || c.Replicas == 0 || c.BillingPeriod == 0 {
+		return ErrInvalidDriveConfig
+	}
+
+	if len(c.Files) > 0 && c.RootHash == nil {
+		return ErrInvalidDriveConfig
+	}
+
+	for _, f := range c.Files {
+		if f == nil || f.FileHash == nil {
+			return ErrInvalidDriveConfig
+		}
+	}
+
+	if c.PollInterval <= 0 {
+		c.PollInterval = time.Second
+	}
+
+	if c.Deadline <= 0 {
+		c.Deadline = time.Hour
+	}
+
+	return nil
+}
+
+// DriveProgress is a persisted state of DriveManager which allows to resume drive lifecycle
+type DriveProgress struct {
+	DriveKey string     `json:"driveKey"`
+	Step     DriveStep  `json:"step"`
+	State    DriveState `json:"state"`
+	// Announced transactions of current step, they are announced again and awaited on resume
+	Announced []*SignedTransaction `json:"announced"`
+	// Expires is a time after deadlines of announced transactions, they are signed again when it passes before confirmation
+	Expires time.Time `json:"expires"`
+}
+
+func (p *DriveProgress) String() string {
+	return str.StructToString(
+		"DriveProgress",
+		str.NewField("DriveKey", str.StringPattern, p.DriveKey),
+		str.NewField("Step", str.StringPattern, p.Step),
+		str.NewField("State", str.IntPattern, p.State),
+		str.NewField("Announced", str.StringPattern, p.Announced),
+		str.NewField("Expires", str.StringPattern, p.Expires),
+	)
+}
+
+// DriveProgressStore persists progress of DriveManager
+type DriveProgressStore interface {
+	// LoadDriveProgress returns nil without error when drive doesn't have progress
+	LoadDriveProgress(driveKey string) (*DriveProgress, error)
+	SaveDriveProgress(progress *DriveProgress) error
+}
+
+type memoryDriveProgressStore struct {
+	sync.Mutex
+	progresses map[string]DriveProgress
+}
+
+// NewMemoryDriveProgressStore returns store which keeps progress only while process is alive
+func NewMemoryDriveProgressStore() DriveProgressStore {
+	return &memoryDriveProgressStore{progresses: make(map[string]DriveProgress)}
+}
+
+func (s *memoryDriveProgressStore) LoadDriveProgress(driveKey string) (*DriveProgress, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	progress, ok := s.progresses[driveKey]
+	if !ok {
+		return nil, nil
+	}
+
+	return &progress, nil
+}
+
+func (s *memoryDriveProgressStore) SaveDriveProgress(progress *DriveProgress) error {
+	s.Lock()
+	defer s.Unlock()
+
+	s.progresses[progress.DriveKey] = *progress
+	return nil
+}
+
+type fileDriveProgressStore struct {
+	dir string
+}
+
+// NewFileDriveProgressStore returns store which keeps progress of every drive in JSON file inside of dir
+func NewFileDriveProgressStore(dir string) (DriveProgressStore, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	return &fileDriveProgressStore{dir: dir}, nil
+}
+
+func (s *fileDriveProgressStore) path(driveKey string) string {
+	return filepath.Join(s.dir, driveKey+".json")
+}
+
+func (s *fileDriveProgressStore) LoadDriveProgress(driveKey string) (*DriveProgress, error) {
+	data, err := ioutil.ReadFile(s.path(driveKey))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	progress := &DriveProgress{}
+	if err := json.Unmarshal(data, progress); err != nil {
+		return nil, err
+	}
+
+	return progress, nil
+}
+
+// SaveDriveProgress writes progress atomically, so crash doesn't leave partially written progress
+func (s *fileDriveProgressStore) SaveDriveProgress(progress *DriveProgress) error {
+	data, err := json.Marshal(progress)
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(s.path(progress.DriveKey), data)
+}
+
+// DriveEvent is emitted by DriveManager when state of drive is changed
+type DriveEvent struct {
+	Drive         *Drive
+	PreviousState DriveState
+	State         DriveState
+	Step          DriveStep
+}
+
+func (e *DriveEvent) String() string {
+	return str.StructToString(
+		"DriveEvent",
+		str.NewField("Drive", str.StringPattern, e.Drive.DriveAccount),
+		str.NewField("PreviousState", str.IntPattern, e.PreviousState),
+		str.NewField("State", str.IntPattern, e.State),
+		str.NewField("Step", str.StringPattern, e.Step),
+	)
+}
+
+type DriveEventHandler func(*DriveEvent)
@@ -0,0 +1,239 @@
+package sdk
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testDriveChain simulates drive state on node, every announced transaction moves drive to the next state
+type testDriveChain struct {
+	sync.Mutex
+	owner, drive *Account
+	announced    []string
+	states       []DriveState
+}
+
+func (c *testDriveChain) driveJson() string {
+	state := c.states[len(c.announced)-1]
+
+	files := ""
+	if len(c.announced) > 2 {
+		files = fmt.Sprintf(`{"fileHash": "%s", "size": [50, 0]}`, testFileHash)
+	}
+
+	return fmt.Sprintf(`{"drive": {
+		"multisig": "%s", "owner": "%s", "state": %d, "start": [1, 0],
+		"rootHash": "%064X", "duration": [3, 0], "billingPeriod": [1, 0], "billingPrice": [50, 0], "size": [1000, 0],
+		"replicas": 1, "minReplicators": 1, "percentApprovers": 100,
+		"billingHistory": [], "files": [%s], "replicators": [], "uploadPayments": []
+	}}`, c.drive.PublicAccount.PublicKey, c.owner.PublicAccount.PublicKey, state, 0, files)
+}
+
+func (c *testDriveChain) register(t *testing.T, m *sdkMock) {
+	m.AddHandler(transactionsRoute, func(resp http.ResponseWriter, req *http.Request) {
+		c.Lock()
+		defer c.Unlock()
+
+		dto := &signedTransactionDto{}
+		assert.Nil(t, json.NewDecoder(req.Body).Decode(dto))
+		c.announced = append(c.announced, dto.Hash)
+
+		resp.WriteHeader(http.StatusAccepted)
+		fmt.Fprint(resp, `{"message": "packet 9 was pushed to the network via /transaction"}`)
+	})
+
+	// only announced transactions are known to node and they are confirmed at once
+	m.AddHandler(strings.TrimSuffix(transactionStatusByIdRoute, "%s"), func(resp http.ResponseWriter, req *http.Request) {
+		c.Lock()
+		defer c.Unlock()
+
+		hash := req.URL.Path[strings.LastIndex(req.URL.Path, "/")+1:]
+		for _, h := range c.announced {
+			if strings.EqualFold(h, hash) {
+				fmt.Fprintf(resp, `{"group": "confirmed", "status": "Success", "hash": "%s", "deadline": [1, 0], "height": [1, 0]}`, hash)
+				return
+			}
+		}
+
+		resp.WriteHeader(http.StatusNotFound)
+	})
+
+	m.AddHandler(fmt.Sprintf(driveRoute, c.drive.PublicAccount.PublicKey), func(resp http.ResponseWriter, req *http.Request) {
+		c.Lock()
+		defer c.Unlock()
+
+		if len(c.announced) == 0 {
+			resp.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		fmt.Fprint(resp, c.driveJson())
+	})
+}
+
+func newTestDriveManagerConfig(t *testing.T) *DriveManagerConfig {
+	owner, err := NewAccount(PublicTest, &Hash{})
+	assert.Nil(t, err)
+	drive, err := NewAccount(PublicTest, &Hash{})
+	assert.Nil(t, err)
+	replicator, err := NewAccount(PublicTest, &Hash{})
+	assert.Nil(t, err)
+
+	return &DriveManagerConfig{
+		Owner:            owner,
+		Drive:            drive,
+		Replicators:      []*Account{replicator},
+		Duration:         3,
+		BillingPeriod:    1,
+		BillingPrice:     50,
+		DriveSize:        1000,
+		Replicas:         1,
+		MinReplicators:   1,
+		PercentApprovers: 100,
+		Files:            []*Action{{FileHash: testFileHash, FileSize: 50}},
+		RootHash:         &Hash{1},
+		Verify:           true,
+		PollInterval:     time.Millisecond,
+	}
+}
+
+func TestDriveManager_Run(t *testing.T) {
+	mock := newSdkMock(0)
+	defer mock.Close()
+
+	config := newTestDriveManagerConfig(t)
+	chain := &testDriveChain{
+		owner: config.Owner,
+		drive: config.Drive,
+		// prepare, join, file system, deposit, start of verification, end of drive
+		states: []DriveState{Pending, InProgress, InProgress, InProgress, InProgress, Finished},
+	}
+	chain.register(t, mock)
+
+	client := mock.getPublicTestClientUnsafe()
+	client.config.GenerationHash = &Hash{}
+
+	manager, err := NewDriveManager(client, config, nil)
+	assert.Nil(t, err)
+
+	events := make([]*DriveEvent, 0)
+	manager.AddHandlers(func(e *DriveEvent) { events = append(events, e) })
+
+	drive, err := manager.Run(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, Finished, drive.State)
+	assert.Len(t, chain.announced, 6)
+
+	assert.Len(t, events, 3)
+	assert.Equal(t, []DriveState{NotStarted, Pending, InProgress}, []DriveState{events[0].PreviousState, events[1].PreviousState, events[2].PreviousState})
+	assert.Equal(t, []DriveState{Pending, InProgress, Finished}, []DriveState{events[0].State, events[1].State, events[2].State})
+	assert.Equal(t, DriveEndStep, events[2].Step)
+
+	progress, err := manager.Progress()
+	assert.Nil(t, err)
+	assert.Equal(t, DriveCompletedStep, progress.Step)
+	assert.Empty(t, progress.Announced)
+}
+
+func TestDriveManager_Resume(t *testing.T) {
+	mock := newSdkMock(0)
+	defer mock.Close()
+
+	dir, err := ioutil.TempDir("", "drive-progress")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	store, err := NewFileDriveProgressStore(dir)
+	assert.Nil(t, err)
+
+	config := newTestDriveManagerConfig(t)
+	client := mock.getPublicTestClientUnsafe()
+	client.config.GenerationHash = &Hash{}
+
+	manager, err := NewDriveManager(client, config, store)
+	assert.Nil(t, err)
+
+	// process crashed after EndDriveTransaction was signed and persisted
+	endTx, err := manager.endDrive(ctx)
+	assert.Nil(t, err)
+	assert.Nil(t, store.SaveDriveProgress(&DriveProgress{
+		DriveKey:  config.Drive.PublicAccount.PublicKey,
+		Step:      DriveEndStep,
+		State:     InProgress,
+		Announced: endTx,
+		Expires:   time.Now().Add(time.Hour),
+	}))
+
+	chain := &testDriveChain{owner: config.Owner, drive: config.Drive, states: []DriveState{Finished}}
+	chain.register(t, mock)
+
+	restarted, err := NewDriveManager(client, config, store)
+	assert.Nil(t, err)
+
+	events := make([]*DriveEvent, 0)
+	restarted.AddHandlers(func(e *DriveEvent) { events = append(events, e) })
+
+	drive, err := restarted.Run(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, Finished, drive.State)
+	assert.Equal(t, []string{endTx[0].Hash.String()}, chain.announced)
+	assert.Len(t, events, 1)
+	assert.Equal(t, InProgress, events[0].PreviousState)
+}
+
+func TestDriveManager_ResumeExpired(t *testing.T) {
+	mock := newSdkMock(0)
+	defer mock.Close()
+
+	config := newTestDriveManagerConfig(t)
+	client := mock.getPublicTestClientUnsafe()
+	client.config.GenerationHash = &Hash{}
+
+	store := NewMemoryDriveProgressStore()
+	manager, err := NewDriveManager(client, config, store)
+	assert.Nil(t, err)
+
+	// process was restarted after deadline of persisted EndDriveTransaction
+	endTx, err := manager.endDrive(ctx)
+	assert.Nil(t, err)
+	assert.Nil(t, store.SaveDriveProgress(&DriveProgress{
+		DriveKey:  config.Drive.PublicAccount.PublicKey,
+		Step:      DriveEndStep,
+		State:     InProgress,
+		Announced: endTx,
+		Expires:   time.Now().Add(-time.Minute),
+	}))
+	config.Deadline = 2 * time.Hour
+
+	chain := &testDriveChain{owner: config.Owner, drive: config.Drive, states: []DriveState{Finished}}
+	chain.register(t, mock)
+
+	drive, err := manager.Run(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, Finished, drive.State)
+	assert.Len(t, chain.announced, 1)
+	assert.NotEqual(t, endTx[0].Hash.String(), chain.announced[0])
+}
+
+func TestNewDriveManager_InvalidConfig(t *testing.T) {
+	_, err := NewDriveManager(nil, &DriveManagerConfig{}, nil)
+	assert.Equal(t, ErrInvalidDriveConfig, err)
+
+	config := newTestDriveManagerConfig(t)
+	config.RootHash = nil
+	_, err = NewDriveManager(nil, config, nil)
+	assert.Equal(t, ErrInvalidDriveConfig, err)
+
+	config = newTestDriveManagerConfig(t)
+	config.Files = []*Action{{FileSize: 50}}
+	_, err = NewDriveManager(nil, config, nil)
+	assert.Equal(t, ErrInvalidDriveConfig, err)
+}
@@ -0,0 +1,100 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"context"
+)
+
+// GetSLAReport returns service levels of replicators of drives at current chain height.
+// Failed verifications are collected from confirmed EndDriveVerificationTransaction of every drive
+func (s *StorageService) GetSLAReport(ctx context.Context, driveKeys ...*PublicAccount) (*SLAReport, error) {
+	if len(driveKeys) == 0 {
+		return nil, ErrEmptyDriveKeys
+	}
+
+	height, err := s.client.Blockchain.GetBlockchainHeight(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &SLAReport{
+		Height: height,
+		Drives: make([]*DriveSLA, 0, len(driveKeys)),
+	}
+
+	for _, driveKey := range driveKeys {
+		if driveKey == nil {
+			return nil, ErrNilAccount
+		}
+
+		drive, err := s.GetDrive(ctx, driveKey)
+		if err != nil {
+			return nil, err
+		}
+
+		status, err := s.GetVerificationStatus(ctx, driveKey)
+		if err != nil {
+			return nil, err
+		}
+
+		failures, err := s.driveVerificationFailures(ctx, driveKey)
+		if err != nil {
+			return nil, err
+		}
+
+		report.Drives = append(report.Drives, newDriveSLA(drive, height, status.Active, failures))
+	}
+
+	return report, nil
+}
+
+// driveVerificationFailures returns failed verifications of drive by replicator key
+func (s *StorageService) driveVerificationFailures(ctx context.Context, driveKey *PublicAccount) (map[string][]*driveVerificationFailure, error) {
+	failures := make(map[string][]*driveVerificationFailure)
+
+	opts := &TransactionsPageOptions{
+		Address:  driveKey.Address.Address,
+		Type:     []uint{uint(EndDriveVerification)},
+		Embedded: true,
+	}
+
+	it := s.client.Transaction.IterateTransactionsByGroup(ctx, Confirmed, opts, nil)
+	defer it.Close()
+
+	for it.Next() {
+		tx := it.Transaction()
+		height := tx.GetAbstractTransaction().Height
+
+		inner := []Transaction{tx}
+		if aggTx, ok := tx.(*AggregateTransaction); ok {
+			inner = aggTx.InnerTransactions
+		}
+
+		for _, innerTx := range inner {
+			verificationTx, ok := innerTx.(*EndDriveVerificationTransaction)
+			if !ok || verificationTx.Signer == nil || verificationTx.Signer.PublicKey != driveKey.PublicKey {
+				continue
+			}
+
+			for _, f := range verificationTx.Failures {
+				if f.Replicator == nil {
+					continue
+				}
+
+				failures[f.Replicator.PublicKey] = append(failures[f.Replicator.PublicKey], &driveVerificationFailure{
+					Height: height,
+					Blocks: len(f.BlochHashes),
+				})
+			}
+		}
+	}
+
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	return failures, nil
+}
@@ -0,0 +1,202 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"encoding/csv"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/proximax-storage/go-xpx-utils/str"
+)
+
+// ReplicatorSLA is a service level of replicator on drive
+type ReplicatorSLA struct {
+	Drive      string `json:"drive"`
+	Replicator string `json:"replicator"`
+	// Start and End are a window where replicator serves drive, End is zero while replicator is active
+	Start Height `json:"start"`
+	End   Height `json:"end"`
+	// Uptime is a share of drive lifetime served by replicator, from 0 to 1
+	Uptime              float64  `json:"uptime"`
+	FilesWithoutDeposit []string `json:"filesWithoutDeposit"`
+	FailedVerifications int      `json:"failedVerifications"`
+	FailedBlocks        int      `json:"failedBlocks"`
+	LastFailure         Height   `json:"lastFailure"`
+}
+
+func (r *ReplicatorSLA) String() string {
+	return str.StructToString(
+		"ReplicatorSLA",
+		str.NewField("Drive", str.StringPattern, r.Drive),
+		str.NewField("Replicator", str.StringPattern, r.Replicator),
+		str.NewField("Start", str.StringPattern, r.Start),
+		str.NewField("End", str.StringPattern, r.End),
+		str.NewField("Uptime", str.FloatPattern, r.Uptime),
+		str.NewField("FilesWithoutDeposit", str.StringPattern, r.FilesWithoutDeposit),
+		str.NewField("FailedVerifications", str.IntPattern, r.FailedVerifications),
+		str.NewField("FailedBlocks", str.IntPattern, r.FailedBlocks),
+		str.NewField("LastFailure", str.StringPattern, r.LastFailure),
+	)
+}
+
+// DriveSLA is a service level of every replicator of drive
+type DriveSLA struct {
+	Drive              string           `json:"drive"`
+	State              DriveState       `json:"state"`
+	Start              Height           `json:"start"`
+	VerificationActive bool             `json:"verificationActive"`
+	Replicators        []*ReplicatorSLA `json:"replicators"`
+}
+
+// SLAReport is a service level report of drives at chain height
+type SLAReport struct {
+	Height Height      `json:"height"`
+	Drives []*DriveSLA `json:"drives"`
+}
+
+// SLAThresholds are limits which replicators should meet
+type SLAThresholds struct {
+	MinUptime              float64
+	MaxFilesWithoutDeposit int
+	MaxFailedVerifications int
+}
+
+// Violations returns replicators which don't meet thresholds
+func (r *SLAReport) Violations(t SLAThresholds) []*ReplicatorSLA {
+	violations := make([]*ReplicatorSLA, 0)
+
+	for _, d := range r.Drives {
+		for _, rep := range d.Replicators {
+			if rep.Uptime < t.MinUptime || len(rep.FilesWithoutDeposit) > t.MaxFilesWithoutDeposit || rep.FailedVerifications > t.MaxFailedVerifications {
+				violations = append(violations, rep)
+			}
+		}
+	}
+
+	return violations
+}
+
+// WriteJSON writes report as JSON document
+func (r *SLAReport) WriteJSON(w io.Writer) error {
+	return json.NewEncoder(w).Encode(r)
+}
+
+// WriteCSV writes one row per replicator of every drive
+func (r *SLAReport) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+
+	header := []string{"height", "drive", "state", "verificationActive", "replicator", "start", "end", "uptime",
+		"filesWithoutDeposit", "failedVerifications", "failedBlocks", "lastFailure"}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for _, d := range r.Drives {
+		for _, rep := range d.Replicators {
+			record := []string{
+				r.Height.String(),
+				d.Drive,
+				strconv.Itoa(int(d.State)),
+				strconv.FormatBool(d.VerificationActive),
+				rep.Replicator,
+				rep.Start.String(),
+				rep.End.String(),
+				strconv.FormatFloat(rep.Uptime, 'f', 4, 64),
+				strings.Join(rep.FilesWithoutDeposit, " "),
+				strconv.Itoa(rep.FailedVerifications),
+				strconv.Itoa(rep.FailedBlocks),
+				rep.LastFailure.String(),
+			}
+
+			if err := cw.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// newDriveSLA returns service levels of replicators of drive at height, failures are verification failures by replicator key
+func newDriveSLA(drive *Drive, height Height, verificationActive bool, failures map[string][]*driveVerificationFailure) *DriveSLA {
+	sla := &DriveSLA{
+		Drive:              drive.DriveAccount.PublicKey,
+		State:              drive.State,
+		Start:              drive.Start,
+		VerificationActive: verificationActive,
+		Replicators:        make([]*ReplicatorSLA, 0, len(drive.Replicators)),
+	}
+
+	// lifetime of drive is limited by its duration and current height
+	lifetimeEnd := height
+	if end := drive.Start + Height(drive.Duration); drive.Duration > 0 && end < lifetimeEnd {
+		lifetimeEnd = end
+	}
+
+	for key, info := range drive.Replicators {
+		rep := &ReplicatorSLA{
+			Drive:               sla.Drive,
+			Replicator:          key,
+			Start:               info.Start,
+			End:                 info.End,
+			FilesWithoutDeposit: make([]string, 0),
+		}
+
+		end := info.End
+		if end == 0 || end > lifetimeEnd {
+			end = lifetimeEnd
+		}
+
+		start := info.Start
+		if start < drive.Start {
+			start = drive.Start
+		}
+
+		if lifetimeEnd > drive.Start {
+			if end > start {
+				rep.Uptime = float64(end-start) / float64(lifetimeEnd-drive.Start)
+			}
+		} else {
+			rep.Uptime = 1
+		}
+
+		for hash, active := range info.ActiveFilesWithoutDeposit {
+			if active {
+				rep.FilesWithoutDeposit = append(rep.FilesWithoutDeposit, hash.String())
+			}
+		}
+		sort.Strings(rep.FilesWithoutDeposit)
+
+		for _, f := range failures[key] {
+			rep.FailedVerifications++
+			rep.FailedBlocks += f.Blocks
+			if f.Height > rep.LastFailure {
+				rep.LastFailure = f.Height
+			}
+		}
+
+		sla.Replicators = append(sla.Replicators, rep)
+	}
+
+	sortReplicatorSLAs(sla.Replicators)
+
+	return sla
+}
+
+func sortReplicatorSLAs(replicators []*ReplicatorSLA) {
+	sort.Slice(replicators, func(i, j int) bool {
+		return replicators[i].Replicator < replicators[j].Replicator
+	})
+}
+
+// driveVerificationFailure is a failed verification of replicator confirmed at height
+type driveVerificationFailure struct {
+	Height Height
+	Blocks int
+}
@@ -0,0 +1,157 @@
+package sdk
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testEndDriveVerificationJson(height int, drive *PublicAccount, replicator *PublicAccount, blocks int) string {
+	hashes := make([]string, blocks)
+	for i := range hashes {
+		hashes[i] = fmt.Sprintf(`"%064X"`, i+1)
+	}
+
+	return fmt.Sprintf(`{
+		"meta": {"height": [%d, 0], "hash": "%064X", "merkleComponentHash": "%064X", "index": 0, "id": "5B686E97F0C0EA00017B9437"},
+		"transaction": {
+			"signature": "%0128X",
+			"signer": "%s",
+			"version": -1879048191,
+			"type": 18522,
+			"maxFee": [0, 0],
+			"deadline": [1094650402, 17],
+			"verificationFailures": [{"replicator": "%s", "blockHashes": [%s]}]
+		}
+	}`, height, height, height, 0, drive.PublicKey, replicator.PublicKey, strings.Join(hashes, ", "))
+}
+
+func TestNewDriveSLA(t *testing.T) {
+	drive := &Drive{
+		DriveAccount: testDriveAccount,
+		State:        InProgress,
+		Start:        100,
+		Duration:     100,
+		Replicators: map[string]*ReplicatorInfo{
+			testReplicatorAccount.PublicKey: {
+				Account:                   testReplicatorAccount,
+				Start:                     120,
+				ActiveFilesWithoutDeposit: map[Hash]bool{{0xFF}: true, *testFileHash: true, {1}: true, {2}: false},
+			},
+			testDriveOwnerAccount.PublicKey: {
+				Account: testDriveOwnerAccount,
+				Start:   100,
+				End:     130,
+			},
+		},
+	}
+
+	failures := map[string][]*driveVerificationFailure{
+		testReplicatorAccount.PublicKey: {{Height: 130, Blocks: 2}, {Height: 140, Blocks: 1}},
+	}
+
+	sla := newDriveSLA(drive, 150, true, failures)
+	assert.True(t, sla.VerificationActive)
+	assert.Len(t, sla.Replicators, 2)
+
+	replicators := make(map[string]*ReplicatorSLA)
+	for _, r := range sla.Replicators {
+		replicators[r.Replicator] = r
+	}
+
+	rep := replicators[testReplicatorAccount.PublicKey]
+	assert.Equal(t, 0.6, rep.Uptime)
+	// files are ordered, so reports of the same drive are equal
+	assert.Equal(t, []string{(&Hash{1}).String(), testFileHash.String(), (&Hash{0xFF}).String()}, rep.FilesWithoutDeposit)
+	assert.Equal(t, 2, rep.FailedVerifications)
+	assert.Equal(t, 3, rep.FailedBlocks)
+	assert.Equal(t, Height(140), rep.LastFailure)
+
+	owner := replicators[testDriveOwnerAccount.PublicKey]
+	assert.Equal(t, 0.6, owner.Uptime)
+	assert.Empty(t, owner.FilesWithoutDeposit)
+	assert.Equal(t, 0, owner.FailedVerifications)
+
+	// lifetime of drive ends with its duration
+	sla = newDriveSLA(drive, 300, false, nil)
+	for _, r := range sla.Replicators {
+		if r.Replicator == testReplicatorAccount.PublicKey {
+			assert.Equal(t, 0.8, r.Uptime)
+		}
+	}
+}
+
+func TestStorageService_GetSLAReport(t *testing.T) {
+	mock := newSdkMock(0)
+	defer mock.Close()
+
+	mock.AddHandler(fmt.Sprintf(driveRoute, testDriveAccount.PublicKey), func(resp http.ResponseWriter, req *http.Request) {
+		fmt.Fprint(resp, testDriveInfoJson)
+	})
+	mock.AddHandler(blockHeightRoute, func(resp http.ResponseWriter, req *http.Request) {
+		fmt.Fprint(resp, `{"height": [2076, 0]}`)
+	})
+
+	pages := []string{
+		testEndDriveVerificationJson(2075, testDriveAccount, testReplicatorAccount, 2),
+		testEndDriveVerificationJson(2076, testDriveAccount, testReplicatorAccount, 1),
+	}
+
+	mock.AddHandler(fmt.Sprintf(transactionsByGroupRoute, Confirmed), func(resp http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, testDriveAccount.Address.Address, req.URL.Query().Get("address"))
+		assert.Equal(t, fmt.Sprint(uint(EndDriveVerification)), req.URL.Query().Get("type[]"))
+
+		page := 1
+		fmt.Sscanf(req.URL.Query().Get("pageNumber"), "%d", &page)
+
+		fmt.Fprintf(resp, `{"data": [%s], "pagination": {"totalEntries": 2, "pageNumber": %d, "pageSize": 1, "totalPages": 2}}`, pages[page-1], page)
+	})
+
+	client := mock.getPublicTestClientUnsafe()
+
+	_, err := client.Storage.GetSLAReport(ctx)
+	assert.Equal(t, ErrEmptyDriveKeys, err)
+
+	report, err := client.Storage.GetSLAReport(ctx, testDriveAccount)
+	assert.Nil(t, err)
+	assert.Equal(t, Height(2076), report.Height)
+	assert.Len(t, report.Drives, 1)
+
+	drive := report.Drives[0]
+	assert.False(t, drive.VerificationActive)
+	assert.Len(t, drive.Replicators, 1)
+
+	// drive of 3 blocks started at 2073 and replicator joined at 2077
+	rep := drive.Replicators[0]
+	assert.Equal(t, testReplicatorAccount.PublicKey, rep.Replicator)
+	assert.Equal(t, float64(0), rep.Uptime)
+	assert.Equal(t, []string{testFileHash.String()}, rep.FilesWithoutDeposit)
+	assert.Equal(t, 2, rep.FailedVerifications)
+	assert.Equal(t, 3, rep.FailedBlocks)
+	assert.Equal(t, Height(2076), rep.LastFailure)
+
+	assert.Equal(t, []*ReplicatorSLA{rep}, report.Violations(SLAThresholds{MinUptime: 0.9, MaxFailedVerifications: 5, MaxFilesWithoutDeposit: 5}))
+	assert.Empty(t, report.Violations(SLAThresholds{MaxFailedVerifications: 5, MaxFilesWithoutDeposit: 5}))
+
+	var buf bytes.Buffer
+	assert.Nil(t, report.WriteCSV(&buf))
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	assert.Nil(t, err)
+	assert.Len(t, records, 2)
+	assert.Equal(t, "replicator", records[0][4])
+	assert.Equal(t, testReplicatorAccount.PublicKey, records[1][4])
+	assert.Equal(t, "2", records[1][9])
+
+	buf.Reset()
+	assert.Nil(t, report.WriteJSON(&buf))
+
+	decoded := &SLAReport{}
+	assert.Nil(t, json.Unmarshal(buf.Bytes(), decoded))
+	assert.Equal(t, report, decoded)
+}
@@ -0,0 +1,266 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/proximax-storage/go-xpx-utils/str"
+	"golang.org/x/crypto/sha3"
+)
+
+// DriveTreeFile is a file of local drive tree
+type DriveTreeFile struct {
+	// Path is a slash separated path relative to root of tree
+	Path string
+	Hash *Hash
+	Size StorageSize
+}
+
+func (f *DriveTreeFile) String() string {
+	return str.StructToString(
+		"DriveTreeFile",
+		str.NewField("Path", str.StringPattern, f.Path),
+		str.NewField("Hash", str.StringPattern, f.Hash),
+		str.NewField("Size", str.StringPattern, f.Size),
+	)
+}
+
+// DriveHasher computes hashes which drive addresses files and its file system by.
+// Hashes are defined by storage layer, so they should be computed the same way as by replicators of drive
+type DriveHasher interface {
+	// FileHash returns hash of file content
+	FileHash(r io.Reader) (*Hash, error)
+	// RootHash returns root hash of drive which stores passed files
+	RootHash(files map[Hash]StorageSize) (*Hash, error)
+}
+
+// LocalDriveHasher is an SDK-local convention of drive hashes: file hash is SHA3-256 of its content and
+// root hash is SHA3-256 of file hashes in ascending order, each followed by its size in little endian.
+// It doesn't match hashes of DFMS, so it suits only drives which file system is maintained by SDK alone
+type LocalDriveHasher struct{}
+
+func (LocalDriveHasher) FileHash(r io.Reader) (*Hash, error) {
+	h := sha3.New256()
+	if _, err := io.Copy(h, r); err != nil {
+		return nil, err
+	}
+
+	return bytesToHash(h.Sum(nil))
+}
+
+// RootHash returns zero hash for empty drive
+func (LocalDriveHasher) RootHash(files map[Hash]StorageSize) (*Hash, error) {
+	if len(files) == 0 {
+		return &Hash{}, nil
+	}
+
+	hashes := make([]Hash, 0, len(files))
+	for hash := range files {
+		hashes = append(hashes, hash)
+	}
+
+	sort.Slice(hashes, func(i, j int) bool {
+		return bytes.Compare(hashes[i][:], hashes[j][:]) < 0
+	})
+
+	h := sha3.New256()
+	sizeB := make([]byte, StorageSizeSize)
+	for _, hash := range hashes {
+		binary.LittleEndian.PutUint64(sizeB, uint64(files[hash]))
+		h.Write(hash[:])
+		h.Write(sizeB)
+	}
+
+	return bytesToHash(h.Sum(nil))
+}
+
+// DriveTree is a local directory which is synchronized with drive.
+// Drive addresses files by hash of their content, so files with equal content are stored once
+type DriveTree struct {
+	Files  []*DriveTreeFile
+	hasher DriveHasher
+}
+
+// NewDriveTree hashes every regular file of directory with hasher, files are ordered by path
+func NewDriveTree(root string, hasher DriveHasher) (*DriveTree, error) {
+	if hasher == nil {
+		return nil, ErrNilDriveHasher
+	}
+
+	tree := &DriveTree{Files: make([]*DriveTreeFile, 0), hasher: hasher}
+
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil || !info.Mode().IsRegular() {
+			return err
+		}
+
+		hash, err := hashFile(hasher, path)
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+
+		tree.Files = append(tree.Files, &DriveTreeFile{
+			Path: filepath.ToSlash(rel),
+			Hash: hash,
+			Size: StorageSize(info.Size()),
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tree, nil
+}
+
+func hashFile(hasher DriveHasher, path string) (*Hash, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return hasher.FileHash(f)
+}
+
+// FileSet returns sizes of distinct files by their hashes like Drive.Files
+func (t *DriveTree) FileSet() map[Hash]StorageSize {
+	files := make(map[Hash]StorageSize, len(t.Files))
+	for _, f := range t.Files {
+		files[*f.Hash] = f.Size
+	}
+
+	return files
+}
+
+// RootHash returns root hash of drive which stores files of tree
+func (t *DriveTree) RootHash() (*Hash, error) {
+	if t.hasher == nil {
+		return nil, ErrNilDriveHasher
+	}
+
+	return t.hasher.RootHash(t.FileSet())
+}
+
+// Diff returns minimal actions which turn files of drive into files of tree, actions are ordered by file hash
+func (t *DriveTree) Diff(drive *Drive) (addActions []*Action, removeActions []*Action) {
+	local := t.FileSet()
+	addActions, removeActions = make([]*Action, 0), make([]*Action, 0)
+
+	for hash, size := range local {
+		if _, ok := drive.Files[hash]; !ok {
+			addActions = append(addActions, &Action{FileHash: hashPtr(hash), FileSize: size})
+		}
+	}
+
+	for hash, size := range drive.Files {
+		if _, ok := local[hash]; !ok {
+			removeActions = append(removeActions, &Action{FileHash: hashPtr(hash), FileSize: size})
+		}
+	}
+
+	sortActions(addActions)
+	sortActions(removeActions)
+
+	return addActions, removeActions
+}
+
+// NewDriveFileSystemTransactionsFromTree returns transactions which synchronize drive with tree.
+// When actions don't fit into maxTransactionSize, they are split into several transactions, which must be
+// confirmed in returned order, because every transaction moves drive to intermediate root hash.
+// Files are removed before adding new ones to free space of drive
+func NewDriveFileSystemTransactionsFromTree(deadline *Deadline, drive *Drive, tree *DriveTree, maxTransactionSize int, networkType NetworkType) ([]*DriveFileSystemTransaction, error) {
+	if drive == nil || drive.DriveAccount == nil || drive.RootHash == nil {
+		return nil, ErrNilAccount
+	}
+
+	if tree.hasher == nil {
+		return nil, ErrNilDriveHasher
+	}
+
+	addActions, removeActions := tree.Diff(drive)
+	if len(addActions)+len(removeActions) == 0 {
+		return nil, ErrNoChanges
+	}
+
+	perTx := (maxTransactionSize - DriveFileSystemHeaderSize) / (Hash256 + StorageSizeSize)
+	if perTx <= 0 {
+		return nil, ErrInvalidDriveTransactionSize
+	}
+
+	files := make(map[Hash]StorageSize, len(drive.Files))
+	for hash, size := range drive.Files {
+		files[hash] = size
+	}
+
+	txs := make([]*DriveFileSystemTransaction, 0)
+	oldRootHash := drive.RootHash
+
+	for len(addActions)+len(removeActions) > 0 {
+		// action counts are serialized as uint16
+		removeCount := minInt(len(removeActions), perTx, math.MaxUint16)
+		addCount := minInt(len(addActions), perTx-removeCount, math.MaxUint16)
+
+		removeChunk, addChunk := removeActions[:removeCount], addActions[:addCount]
+		removeActions, addActions = removeActions[removeCount:], addActions[addCount:]
+
+		for _, a := range removeChunk {
+			delete(files, *a.FileHash)
+		}
+
+		for _, a := range addChunk {
+			files[*a.FileHash] = a.FileSize
+		}
+
+		newRootHash, err := tree.hasher.RootHash(files)
+		if err != nil {
+			return nil, err
+		}
+
+		tx, err := NewDriveFileSystemTransaction(deadline, drive.DriveAccount.PublicKey, newRootHash, oldRootHash, addChunk, removeChunk, networkType)
+		if err != nil {
+			return nil, err
+		}
+
+		txs = append(txs, tx)
+		oldRootHash = newRootHash
+	}
+
+	return txs, nil
+}
+
+func sortActions(actions []*Action) {
+	sort.Slice(actions, func(i, j int) bool {
+		return bytes.Compare(actions[i].FileHash[:], actions[j].FileHash[:]) < 0
+	})
+}
+
+func hashPtr(hash Hash) *Hash {
+	return &hash
+}
+
+func minInt(values ...int) int {
+	min := values[0]
+	for _, v := range values[1:] {
+		if v < min {
+			min = v
+		}
+	}
+
+	return min
+}
@@ -0,0 +1,134 @@
+package sdk
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/sha3"
+)
+
+func newTestDriveTreeDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "drive-tree")
+	assert.Nil(t, err)
+
+	assert.Nil(t, os.MkdirAll(filepath.Join(dir, "sub"), 0700))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "a.txt"), []byte("hello"), 0600))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "sub", "b.txt"), []byte("world"), 0600))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "c.txt"), []byte("hello"), 0600))
+
+	return dir
+}
+
+func testContentHash(content string) Hash {
+	return sha3.Sum256([]byte(content))
+}
+
+func TestNewDriveTree(t *testing.T) {
+	dir := newTestDriveTreeDir(t)
+	defer os.RemoveAll(dir)
+
+	tree, err := NewDriveTree(dir, LocalDriveHasher{})
+	assert.Nil(t, err)
+	assert.Len(t, tree.Files, 3)
+	assert.Equal(t, "sub/b.txt", tree.Files[2].Path)
+	assert.Equal(t, testContentHash("world"), *tree.Files[2].Hash)
+	assert.Equal(t, StorageSize(5), tree.Files[2].Size)
+
+	// files with equal content are stored once
+	assert.Equal(t, map[Hash]StorageSize{
+		testContentHash("hello"): 5,
+		testContentHash("world"): 5,
+	}, tree.FileSet())
+
+	rootHash, err := tree.RootHash()
+	assert.Nil(t, err)
+	assert.NotEqual(t, &Hash{}, rootHash)
+
+	emptyHash, err := LocalDriveHasher{}.RootHash(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, &Hash{}, emptyHash)
+
+	_, err = NewDriveTree(dir, nil)
+	assert.Equal(t, ErrNilDriveHasher, err)
+}
+
+// testPrefixHasher differs from LocalDriveHasher to check that transactions take hashes of passed hasher
+type testPrefixHasher struct{}
+
+func (testPrefixHasher) FileHash(r io.Reader) (*Hash, error) {
+	return LocalDriveHasher{}.FileHash(io.MultiReader(strings.NewReader("file"), r))
+}
+
+func (testPrefixHasher) RootHash(files map[Hash]StorageSize) (*Hash, error) {
+	return &Hash{byte(len(files))}, nil
+}
+
+func TestDriveTree_Hasher(t *testing.T) {
+	dir := newTestDriveTreeDir(t)
+	defer os.RemoveAll(dir)
+
+	tree, err := NewDriveTree(dir, testPrefixHasher{})
+	assert.Nil(t, err)
+	assert.Equal(t, testContentHash("fileworld"), *tree.Files[2].Hash)
+
+	txs, err := NewDriveFileSystemTransactionsFromTree(NewDeadline(time.Hour), &Drive{DriveAccount: testDriveAccount, RootHash: &Hash{}}, tree, 1024, PublicTest)
+	assert.Nil(t, err)
+	assert.Equal(t, &Hash{2}, txs[0].NewRootHash)
+}
+
+func TestDriveTree_Diff(t *testing.T) {
+	dir := newTestDriveTreeDir(t)
+	defer os.RemoveAll(dir)
+
+	tree, err := NewDriveTree(dir, LocalDriveHasher{})
+	assert.Nil(t, err)
+
+	removed := testContentHash("removed")
+	drive := &Drive{
+		DriveAccount: testDriveAccount,
+		RootHash:     &Hash{1},
+		Files: map[Hash]StorageSize{
+			testContentHash("world"): 5,
+			removed:                  7,
+		},
+	}
+
+	hello := testContentHash("hello")
+	addActions, removeActions := tree.Diff(drive)
+	assert.Equal(t, []*Action{{FileHash: &hello, FileSize: 5}}, addActions)
+	assert.Equal(t, []*Action{{FileHash: &removed, FileSize: 7}}, removeActions)
+
+	txs, err := NewDriveFileSystemTransactionsFromTree(NewDeadline(time.Hour), drive, tree, 1024, PublicTest)
+	assert.Nil(t, err)
+	assert.Len(t, txs, 1)
+	assert.Equal(t, drive.RootHash, txs[0].OldRootHash)
+	rootHash, err := tree.RootHash()
+	assert.Nil(t, err)
+	assert.Equal(t, rootHash, txs[0].NewRootHash)
+
+	// every transaction fits only one action
+	txs, err = NewDriveFileSystemTransactionsFromTree(NewDeadline(time.Hour), drive, tree, DriveFileSystemHeaderSize+Hash256+StorageSizeSize, PublicTest)
+	assert.Nil(t, err)
+	assert.Len(t, txs, 2)
+	assert.Equal(t, removeActions, txs[0].RemoveActions)
+	assert.Empty(t, txs[0].AddActions)
+	intermediateHash, err := LocalDriveHasher{}.RootHash(map[Hash]StorageSize{testContentHash("world"): 5})
+	assert.Nil(t, err)
+	assert.Equal(t, intermediateHash, txs[0].NewRootHash)
+	assert.Equal(t, txs[0].NewRootHash, txs[1].OldRootHash)
+	assert.Equal(t, addActions, txs[1].AddActions)
+	assert.Equal(t, rootHash, txs[1].NewRootHash)
+
+	_, err = NewDriveFileSystemTransactionsFromTree(NewDeadline(time.Hour), drive, tree, DriveFileSystemHeaderSize, PublicTest)
+	assert.Equal(t, ErrInvalidDriveTransactionSize, err)
+
+	drive.Files = tree.FileSet()
+	_, err = NewDriveFileSystemTransactionsFromTree(NewDeadline(time.Hour), drive, tree, 1024, PublicTest)
+	assert.Equal(t, ErrNoChanges, err)
+}
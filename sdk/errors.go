@@ -53,6 +53,15 @@ var (
 	ErrWrongBitMosaicId      = errors.New("mosaicId has 64th bit")
 	ErrInvalidOwnerPublicKey = errors.New("public owner key is invalid")
 	ErrNilMosaicProperties   = errors.New("mosaic properties must not be nil")
+	ErrNilMosaic             = errors.New("mosaic must not be nil")
+	ErrInvalidMosaicSpec     = errors.New("mosaic spec should contain non-negative supply and levy with recipient")
+	ErrMosaicSpecConflict    = errors.New("existing mosaic can't be changed to match spec")
+	ErrInvalidDivisibility   = errors.New("divisibility of mosaic should not be greater than 18")
+	ErrInvalidMosaicAmount   = errors.New("amount of mosaic should be non-negative decimal with at most divisibility fraction digits")
+	ErrMosaicAmountOverflow  = errors.New("amount of mosaic is out of range")
+	ErrMosaicAmountMismatch  = errors.New("amounts of different mosaics can't be combined")
+	ErrMosaicNameMismatch    = errors.New("name of mosaic doesn't match asset of amount")
+	ErrInsufficientBalance   = errors.New("sender doesn't have enough mosaics to cover transfer with levy and fee")
 )
 
 // Namespace errors
@@ -62,6 +71,10 @@ var (
 	ErrWrongBitNamespaceId  = errors.New("namespaceId doesn't have 64th bit")
 	ErrEmptyNamespaceIds    = errors.New("list namespace ids must not by empty")
 	ErrInvalidNamespaceName = errors.New("namespace name is invalid")
+	ErrInvalidRenewalConfig = errors.New("renewal config should contain positive duration and non-negative margin and fee")
+	ErrNamespaceNameUnknown = errors.New("node doesn't return name of namespace")
+	ErrNamespaceNotOwned    = errors.New("namespace is owned by another account")
+	ErrInvalidNamespaceLink = errors.New("namespace can be linked either to address or to mosaic")
 )
 
 // Blockchain errors
@@ -76,6 +89,83 @@ var (
 	ErrNilProof  = errors.New("Proof should not be nil")
 )
 
+// Message errors
+var (
+	ErrInvalidSecureMessage     = errors.New("Secure message is malformed or was tampered")
+	ErrNoMessageRecipients      = errors.New("Secure message should have at least one recipient")
+	ErrTooManyMessageRecipients = errors.New("Secure message can not have more than 255 recipients")
+	ErrNotMessageRecipient      = errors.New("Account is not a recipient of the secure message")
+)
+
+// BLS errors
+var (
+	ErrInvalidBLSPrivateKey       = errors.New("BLS private key is invalid")
+	ErrInvalidBLSPublicKey        = errors.New("BLS public key is invalid")
+	ErrInvalidBLSSignature        = errors.New("BLS signature is invalid")
+	ErrInvalidProofOfPossession   = errors.New("BLS proof of possession is invalid")
+	ErrUnknownBLSCommitteeMember  = errors.New("BLS public key is not a member of committee")
+	ErrBLSSignersSignaturesLength = errors.New("count of BLS signers and signatures should be equal")
+)
+
+// Account generation errors
+var (
+	ErrInvalidAccountsCount    = errors.New("count of accounts should be positive")
+	ErrInvalidAddressPattern   = errors.New("address pattern should contain base32 characters and start with the network letter")
+	ErrInvalidKeystore         = errors.New("keystore is invalid")
+	ErrInvalidKeystorePassword = errors.New("keystore password is wrong or keystore is corrupted")
+)
+
+// Drive errors
+var (
+	ErrTransactionFailed           = errors.New("transaction is rejected by node")
+	ErrInvalidDriveConfig          = errors.New("drive config should contain owner, drive account and drive parameters")
+	ErrInvalidDriveTransactionSize = errors.New("max transaction size should fit at least one drive action")
+	ErrInvalidDriveBilling         = errors.New("drive duration should be multiple of positive billing period and replicas should be positive")
+	ErrNilDriveHasher              = errors.New("drive hasher should not be nil")
+	ErrEmptyDriveKeys              = errors.New("list of drive keys should not be empty")
+)
+
+// Download errors
+var (
+	ErrInvalidDownloadConfig       = errors.New("download config should contain recipient, drive and files")
+	ErrDownloadFileTooLarge        = errors.New("size of file exceeds limit of download")
+	ErrDownloadFileNotFound        = errors.New("file is not stored on drive")
+	ErrInsufficientStreamingBudget = errors.New("recipient doesn't have enough streaming units for download")
+	ErrDownloadNotFound            = errors.New("download is not found on node")
+)
+
+// SuperContract errors
+var (
+	ErrInvalidSuperContractConfig = errors.New("supercontract client config should contain initiator")
+	ErrInvalidDeployerConfig      = errors.New("supercontract deployer config should contain owner, drive and hasher")
+	ErrOperationNotFound          = errors.New("operation is not found on node")
+	ErrEmptyContractFile          = errors.New("supercontract file should not be empty")
+	ErrContractFileNotStored      = errors.New("supercontract file should be uploaded to drive before deploy")
+	ErrAbiFunctionNotFound        = errors.New("function is not described by ABI schema")
+	ErrAbiInvalidType             = errors.New("ABI parameter type is unknown or tuple doesn't have components")
+	ErrAbiInvalidValue            = errors.New("value doesn't match type of ABI parameter")
+	ErrAbiArgumentsCount          = errors.New("count of arguments doesn't match ABI parameters")
+	ErrAbiInvalidData             = errors.New("parameter vector doesn't match ABI parameters")
+)
+
+// Exchange errors
+var (
+	ErrUnknownOfferType         = errors.New("offer type should be sell or buy")
+	ErrInsufficientLiquidity    = errors.New("offers don't have enough mosaic to fill amount")
+	ErrInvalidFillAmount        = errors.New("amount to fill should be positive")
+	ErrInvalidOfferPrice        = errors.New("offer price should be non-negative with positive denominator")
+	ErrInvalidOfferAmount       = errors.New("offer amount should not be negative")
+	ErrOfferCostOverflow        = errors.New("offer cost doesn't fit into amount")
+	ErrInvalidMarketMakerConfig = errors.New("market maker config should contain owner, mosaic and strategy")
+	ErrInvalidMarketQuote       = errors.New("strategy should quote at most one sell and one buy offer")
+	ErrNoMarketPrice            = errors.New("order book doesn't have offers to derive reference price")
+)
+
+// Iterator errors
+var (
+	ErrInvalidIteratorToken = errors.New("iterator token should be in format <page>.<index> with positive page")
+)
+
 // plain errors
 var (
 	ErrEmptyAddressesIds = errors.New("list of addresses should not be empty")
@@ -7,7 +7,6 @@ package sdk
 import (
 	"context"
 	"fmt"
-	"github.com/pkg/errors"
 	"github.com/proximax-storage/go-xpx-utils/net"
 	"net/http"
 )
@@ -41,19 +40,9 @@ func (e *ExchangeService) GetAccountExchangeInfo(ctx context.Context, account *P
 // Return offers with same operation type and mosaic id.
 // Example: If you want to buy Storage units, you need to call GetExchangeOfferByAssetId(StorageMosaicId, SellOffer)
 func (e *ExchangeService) GetExchangeOfferByAssetId(ctx context.Context, assetId AssetId, offerType OfferType) ([]*OfferInfo, error) {
-	var mosaicId *MosaicId
-
-	switch assetId.Type() {
-	case NamespaceAssetIdType:
-		mosaicInfo, err := e.ResolveService.GetMosaicInfoByAssetId(ctx, assetId)
-		if err != nil {
-			return nil, err
-		}
-		mosaicId = mosaicInfo.MosaicId
-	case MosaicAssetIdType:
-		mosaicId = assetId.(*MosaicId)
-	default:
-		return nil, errors.New("unknown assetID type")
+	mosaicId, err := e.ResolveService.ResolveMosaicId(ctx, assetId)
+	if err != nil {
+		return nil, err
 	}
 
 	url := net.NewUrl(fmt.Sprintf(offersByMosaicRoute, offerType.String(), mosaicId.toHexString()))
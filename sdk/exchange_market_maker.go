@@ -0,0 +1,186 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"context"
+)
+
+// MarketMaker keeps offers of owner on exchange in line with strategy
+type MarketMaker struct {
+	client   *Client
+	config   *MarketMakerConfig
+	strategy MarketStrategy
+	market   MarketSource
+}
+
+// NewMarketMaker returns market maker which reads state of exchange from market, client.Exchange is used when market is nil
+func NewMarketMaker(client *Client, config *MarketMakerConfig, strategy MarketStrategy, market MarketSource) (*MarketMaker, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	if strategy == nil {
+		return nil, ErrInvalidMarketMakerConfig
+	}
+
+	if market == nil {
+		market = client.Exchange
+	}
+
+	return &MarketMaker{
+		client:   client,
+		config:   config,
+		strategy: strategy,
+		market:   market,
+	}, nil
+}
+
+// Plan returns changes of offers which reconcile current offers of owner with quotes of strategy
+func (m *MarketMaker) Plan(ctx context.Context) (*MarketPlan, error) {
+	state, err := m.market.GetMarketState(ctx, m.config.Owner.PublicAccount, m.config.MosaicId)
+	if err != nil {
+		return nil, err
+	}
+
+	quotes, err := m.strategy.Quote(state)
+	if err != nil {
+		return nil, err
+	}
+
+	return reconcileOffers(state, quotes, m.config.RenewBefore)
+}
+
+// Step plans changes of offers and announces them in one aggregate transaction, so offers are never
+// left half updated. In dry run plan is only returned
+func (m *MarketMaker) Step(ctx context.Context) (*MarketPlan, error) {
+	plan, err := m.Plan(ctx)
+	if err != nil || plan.IsEmpty() {
+		return plan, err
+	}
+
+	if m.config.DryRun {
+		if recorded, ok := m.market.(*RecordedMarket); ok {
+			recorded.apply(m.config.Owner.PublicAccount, plan)
+		}
+
+		return plan, nil
+	}
+
+	txs := make([]Transaction, 0, 2)
+
+	if len(plan.Remove) > 0 {
+		tx, err := m.client.NewRemoveExchangeOfferTransaction(m.deadline(), plan.Remove)
+		if err != nil {
+			return nil, err
+		}
+
+		tx.ToAggregate(m.config.Owner.PublicAccount)
+		txs = append(txs, tx)
+	}
+
+	if len(plan.Add) > 0 {
+		tx, err := m.client.NewAddExchangeOfferTransaction(m.deadline(), plan.Add)
+		if err != nil {
+			return nil, err
+		}
+
+		tx.ToAggregate(m.config.Owner.PublicAccount)
+		txs = append(txs, tx)
+	}
+
+	aggTx, err := m.client.NewCompleteAggregateTransaction(m.deadline(), txs)
+	if err != nil {
+		return nil, err
+	}
+
+	signed, err := m.config.Owner.Sign(aggTx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := m.client.Transaction.announceAndWait(ctx, signed, m.config.PollInterval); err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+// Run reconciles offers every interval until context is done or step fails.
+// Every applied plan is passed to onPlan when it isn't nil
+func (m *MarketMaker) Run(ctx context.Context, onPlan func(*MarketPlan)) error {
+	return poll(ctx, m.config.Interval, func() (bool, error) {
+		plan, err := m.Step(ctx)
+		if err != nil {
+			return false, err
+		}
+
+		if onPlan != nil && !plan.IsEmpty() {
+			onPlan(plan)
+		}
+
+		return false, nil
+	})
+}
+
+func (m *MarketMaker) deadline() *Deadline {
+	return NewDeadline(m.config.Deadline)
+}
+
+// GetMarketState returns order book of mosaic with active offers and inventory of owner
+func (e *ExchangeService) GetMarketState(ctx context.Context, owner *PublicAccount, mosaicId *MosaicId) (*MarketState, error) {
+	if owner == nil {
+		return nil, ErrNilAccount
+	}
+
+	if mosaicId == nil {
+		return nil, ErrNilMosaicId
+	}
+
+	book, err := e.GetOrderBook(ctx, mosaicId)
+	if err != nil {
+		return nil, err
+	}
+
+	state := &MarketState{
+		Height: book.Height,
+		Owner:  owner,
+		Book:   book,
+		Offers: make(map[OfferType]*OfferInfo),
+	}
+
+	info, err := e.GetAccountExchangeInfo(ctx, owner)
+	if err != nil && !isNotFoundError(err) {
+		return nil, err
+	}
+
+	if info != nil {
+		for offerType, offers := range info.Offers {
+			if o, ok := offers[*mosaicId]; ok && o.Deadline > book.Height {
+				state.Offers[offerType] = o
+			}
+		}
+	}
+
+	account, err := e.client.Account.GetAccountInfo(ctx, owner.Address)
+	if err != nil && !isNotFoundError(err) {
+		return nil, err
+	}
+
+	if account != nil {
+		for _, m := range account.Mosaics {
+			if m.AssetId.Id() == mosaicId.Id() {
+				state.Inventory += m.Amount
+			}
+		}
+	}
+
+	// mosaic of sell offer is locked on exchange, but it still belongs to owner
+	if o, ok := state.Offers[SellOffer]; ok {
+		state.Inventory += o.Mosaic.Amount
+	}
+
+	return state, nil
+}
@@ -0,0 +1,307 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/proximax-storage/go-xpx-utils/str"
+)
+
+// MarketMakerConfig is a configuration of MarketMaker
+type MarketMakerConfig struct {
+	Owner    *Account
+	MosaicId *MosaicId
+	// Interval is a period between reconciliations of Run
+	Interval time.Duration
+	// RenewBefore is a count of blocks before deadline of offer when it is replaced with new one
+	RenewBefore Duration
+	// DryRun makes market maker only plan changes without announcing them.
+	// When market is RecordedMarket, plans are applied to recorded state
+	DryRun       bool
+	PollInterval time.Duration
+	Deadline     time.Duration
+}
+
+func (c *MarketMakerConfig) validate() error {
+	if c == nil || c.Owner == nil || c.MosaicId == nil {
+		return ErrInvalidMarketMakerConfig
+	}
+
+	if c.Interval <= 0 {
+		c.Interval = time.Minute
+	}
+
+	if c.PollInterval <= 0 {
+		c.PollInterval = time.Second
+	}
+
+	if c.Deadline <= 0 {
+		c.Deadline = time.Hour
+	}
+
+	return nil
+}
+
+// MarketState is a state of exchange which market maker reconciles its offers with
+type MarketState struct {
+	Height Height
+	Owner  *PublicAccount
+	// Book contains active offers of every account including offers of owner
+	Book *OrderBook
+	// Offers are active offers of owner by type
+	Offers map[OfferType]*OfferInfo
+	// Inventory is a balance of mosaic of owner including mosaic locked in its sell offer
+	Inventory Amount
+}
+
+func (s *MarketState) String() string {
+	return str.StructToString(
+		"MarketState",
+		str.NewField("Height", str.StringPattern, s.Height),
+		str.NewField("Owner", str.StringPattern, s.Owner),
+		str.NewField("Book", str.StringPattern, s.Book),
+		str.NewField("Offers", str.StringPattern, s.Offers),
+		str.NewField("Inventory", str.StringPattern, s.Inventory),
+	)
+}
+
+// MarketSource provides state of exchange to market maker, ExchangeService reads it from chain
+type MarketSource interface {
+	GetMarketState(ctx context.Context, owner *PublicAccount, mosaicId *MosaicId) (*MarketState, error)
+}
+
+// MarketStrategy decides which offers market maker keeps on exchange.
+// Strategy returns at most one offer of every type, market maker removes its offers of missing types
+type MarketStrategy interface {
+	Quote(state *MarketState) ([]*AddOffer, error)
+}
+
+// SpreadStrategy quotes sell and buy offers around reference price
+type SpreadStrategy struct {
+	// Price is a reference price of one unit of mosaic, when it is nil, middle of the best offers
+	// of other accounts is used
+	Price *big.Rat
+	// Spread is a relative distance between sell and buy prices, 1/50 puts sell price 1% above
+	// reference price and buy price 1% below
+	Spread *big.Rat
+	// Size is an amount of mosaic in every offer
+	Size Amount
+	// Sell offer never takes inventory below MinInventory and buy offer never takes it above MaxInventory
+	MinInventory Amount
+	MaxInventory Amount
+	Duration     Duration
+}
+
+// Quote implements MarketStrategy
+func (s *SpreadStrategy) Quote(state *MarketState) ([]*AddOffer, error) {
+	price := s.Price
+	if price == nil {
+		price = referencePrice(state)
+		if price == nil {
+			return nil, ErrNoMarketPrice
+		}
+	}
+
+	halfSpread := new(big.Rat)
+	if s.Spread != nil {
+		halfSpread.Quo(s.Spread, big.NewRat(2, 1))
+	}
+
+	offers := make([]*AddOffer, 0, 2)
+
+	if size := minAmount(s.Size, state.Inventory-s.MinInventory); size > 0 {
+		sellPrice := new(big.Rat).Mul(price, new(big.Rat).Add(big.NewRat(1, 1), halfSpread))
+
+		offer, err := NewAddOffer(SellOffer, newMosaicPanic(state.Book.AssetId, size), sellPrice, s.Duration)
+		if err != nil {
+			return nil, err
+		}
+
+		offers = append(offers, offer)
+	}
+
+	if size := minAmount(s.Size, s.MaxInventory-state.Inventory); size > 0 {
+		buyPrice := new(big.Rat).Mul(price, new(big.Rat).Sub(big.NewRat(1, 1), halfSpread))
+
+		offer, err := NewAddOffer(BuyOffer, newMosaicPanic(state.Book.AssetId, size), buyPrice, s.Duration)
+		if err != nil {
+			return nil, err
+		}
+
+		offers = append(offers, offer)
+	}
+
+	return offers, nil
+}
+
+// referencePrice returns middle of the best offers of other accounts, or price of the only side of book
+func referencePrice(state *MarketState) *big.Rat {
+	bestSell, bestBuy := bestForeignOffer(state.Book.Sell, state.Owner), bestForeignOffer(state.Book.Buy, state.Owner)
+
+	switch {
+	case bestSell != nil && bestBuy != nil:
+		price := new(big.Rat).Add(bestSell.Price(), bestBuy.Price())
+		return price.Quo(price, big.NewRat(2, 1))
+	case bestSell != nil:
+		return bestSell.Price()
+	case bestBuy != nil:
+		return bestBuy.Price()
+	default:
+		return nil
+	}
+}
+
+func bestForeignOffer(offers []*OfferInfo, owner *PublicAccount) *OfferInfo {
+	for _, o := range offers {
+		if owner == nil || o.Owner == nil || o.Owner.PublicKey != owner.PublicKey {
+			return o
+		}
+	}
+
+	return nil
+}
+
+func minAmount(a, b Amount) Amount {
+	if a < b {
+		return a
+	}
+
+	return b
+}
+
+// MarketPlan is a set of changes of market maker offers, offers are removed before new ones are added.
+// Changed offer is removed and added again
+type MarketPlan struct {
+	Add    []*AddOffer
+	Remove []*RemoveOffer
+}
+
+func (p *MarketPlan) String() string {
+	return str.StructToString(
+		"MarketPlan",
+		str.NewField("Add", str.StringPattern, p.Add),
+		str.NewField("Remove", str.StringPattern, p.Remove),
+	)
+}
+
+// IsEmpty returns true when plan doesn't change offers
+func (p *MarketPlan) IsEmpty() bool {
+	return len(p.Add) == 0 && len(p.Remove) == 0
+}
+
+// reconcileOffers returns plan which turns offers of state into desired offers.
+// Offer is kept when it has the same price and amount and doesn't expire within renewBefore blocks
+func reconcileOffers(state *MarketState, desired []*AddOffer, renewBefore Duration) (*MarketPlan, error) {
+	byType := make(map[OfferType]*AddOffer, len(desired))
+	for _, o := range desired {
+		if _, ok := byType[o.Type]; ok || (o.Type != SellOffer && o.Type != BuyOffer) {
+			return nil, ErrInvalidMarketQuote
+		}
+
+		byType[o.Type] = o
+	}
+
+	plan := &MarketPlan{
+		Add:    make([]*AddOffer, 0),
+		Remove: make([]*RemoveOffer, 0),
+	}
+
+	for _, offerType := range []OfferType{SellOffer, BuyOffer} {
+		current, want := state.Offers[offerType], byType[offerType]
+
+		if current != nil && want != nil && sameOffer(current, want) && current.Deadline > state.Height+Height(renewBefore) {
+			continue
+		}
+
+		if current != nil {
+			plan.Remove = append(plan.Remove, &RemoveOffer{Type: offerType, AssetId: current.Mosaic.AssetId})
+		}
+
+		if want != nil {
+			plan.Add = append(plan.Add, want)
+		}
+	}
+
+	return plan, nil
+}
+
+func sameOffer(current *OfferInfo, want *AddOffer) bool {
+	if current.Mosaic.Amount != want.Mosaic.Amount {
+		return false
+	}
+
+	price, err := NewOfferPrice(want.Cost, want.Mosaic.Amount)
+	if err != nil {
+		return false
+	}
+
+	currentPrice := current.Price()
+	return currentPrice != nil && currentPrice.Cmp(price) == 0
+}
+
+// RecordedMarket is a recorded state of exchange for dry run of market maker.
+// Plans are applied to recorded state instead of chain, so following reconciliations see previous changes
+type RecordedMarket struct {
+	lock  sync.Mutex
+	state *MarketState
+}
+
+// NewRecordedMarket returns market with state, offers of owner are taken from book
+func NewRecordedMarket(state *MarketState) *RecordedMarket {
+	return &RecordedMarket{state: state}
+}
+
+// GetMarketState implements MarketSource
+func (m *RecordedMarket) GetMarketState(ctx context.Context, owner *PublicAccount, mosaicId *MosaicId) (*MarketState, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	state := *m.state
+	state.Owner = owner
+	state.Offers = make(map[OfferType]*OfferInfo)
+
+	for _, o := range append(append([]*OfferInfo{}, state.Book.Sell...), state.Book.Buy...) {
+		if o.Owner != nil && o.Owner.PublicKey == owner.PublicKey {
+			state.Offers[o.Type] = o
+		}
+	}
+
+	return &state, nil
+}
+
+// apply replaces offers of owner in recorded book with offers of plan
+func (m *RecordedMarket) apply(owner *PublicAccount, plan *MarketPlan) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	removed := make(map[OfferType]bool, len(plan.Remove))
+	for _, r := range plan.Remove {
+		removed[r.Type] = true
+	}
+
+	offers := make([]*OfferInfo, 0)
+	for _, o := range append(append([]*OfferInfo{}, m.state.Book.Sell...), m.state.Book.Buy...) {
+		if o.Owner == nil || o.Owner.PublicKey != owner.PublicKey || !removed[o.Type] {
+			offers = append(offers, o)
+		}
+	}
+
+	for _, a := range plan.Add {
+		offers = append(offers, &OfferInfo{
+			Type:             a.Type,
+			Owner:            owner,
+			Mosaic:           a.Mosaic,
+			PriceNumerator:   a.Cost,
+			PriceDenominator: a.Mosaic.Amount,
+			Deadline:         m.state.Height + Height(a.Duration),
+		})
+	}
+
+	m.state.Book = NewOrderBook(m.state.Book.AssetId, m.state.Height, offers...)
+}
@@ -0,0 +1,191 @@
+package sdk
+
+import (
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/proximax-storage/go-xpx-utils/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+var testMarketOwner, _ = NewAccountFromPublicKey("1D2DE3B1A0D2E3F4C5B6A79881726354453627180918273645546372819A0B0C", PublicTest)
+
+func newTestMarketState(inventory Amount, offers ...*OfferInfo) *MarketState {
+	state := &MarketState{
+		Height:    10,
+		Owner:     testMarketOwner,
+		Book:      NewOrderBook(exchangeMosaicId, 10, offers...),
+		Offers:    make(map[OfferType]*OfferInfo),
+		Inventory: inventory,
+	}
+
+	for _, o := range offers {
+		if o.Owner == testMarketOwner {
+			state.Offers[o.Type] = o
+		}
+	}
+
+	return state
+}
+
+func TestSpreadStrategy_Quote(t *testing.T) {
+	strategy := &SpreadStrategy{
+		Spread:       big.NewRat(1, 5),
+		Size:         10,
+		MaxInventory: 100,
+		Duration:     100,
+	}
+
+	own := newTestOffer(SellOffer, 10, 1, 1, 100)
+	own.Owner = testMarketOwner
+
+	// reference price is 3/2, own offers are ignored
+	state := newTestMarketState(50, newTestOffer(SellOffer, 10, 2, 1, 100), newTestOffer(BuyOffer, 10, 1, 1, 100), own)
+	quotes, err := strategy.Quote(state)
+	assert.Nil(t, err)
+	assert.Len(t, quotes, 2)
+	assert.Equal(t, SellOffer, quotes[0].Type)
+	assert.Equal(t, Amount(10), quotes[0].Mosaic.Amount)
+	assert.Equal(t, Amount(17), quotes[0].Cost)
+	assert.Equal(t, BuyOffer, quotes[1].Type)
+	assert.Equal(t, Amount(13), quotes[1].Cost)
+
+	// inventory limits sizes of offers
+	strategy.MaxInventory = 12
+	state.Inventory = 5
+	quotes, err = strategy.Quote(state)
+	assert.Nil(t, err)
+	assert.Len(t, quotes, 2)
+	assert.Equal(t, Amount(5), quotes[0].Mosaic.Amount)
+	assert.Equal(t, Amount(9), quotes[0].Cost)
+	assert.Equal(t, Amount(7), quotes[1].Mosaic.Amount)
+	assert.Equal(t, Amount(9), quotes[1].Cost)
+
+	state.Inventory = 12
+	quotes, err = strategy.Quote(state)
+	assert.Nil(t, err)
+	assert.Len(t, quotes, 1)
+	assert.Equal(t, SellOffer, quotes[0].Type)
+
+	_, err = strategy.Quote(newTestMarketState(5, own))
+	assert.Equal(t, ErrNoMarketPrice, err)
+}
+
+func TestReconcileOffers(t *testing.T) {
+	sell := newTestOffer(SellOffer, 10, 20, 10, 100)
+	sell.Owner = testMarketOwner
+	buy := newTestOffer(BuyOffer, 10, 10, 10, 15)
+	buy.Owner = testMarketOwner
+	state := newTestMarketState(10, sell, buy)
+
+	sameSell, err := NewAddOffer(SellOffer, newMosaicPanic(exchangeMosaicId, 10), big.NewRat(2, 1), 100)
+	assert.Nil(t, err)
+	sameBuy, err := NewAddOffer(BuyOffer, newMosaicPanic(exchangeMosaicId, 10), big.NewRat(1, 1), 100)
+	assert.Nil(t, err)
+
+	plan, err := reconcileOffers(state, []*AddOffer{sameSell, sameBuy}, 0)
+	assert.Nil(t, err)
+	assert.True(t, plan.IsEmpty())
+
+	// buy offer expires within 10 blocks
+	plan, err = reconcileOffers(state, []*AddOffer{sameSell, sameBuy}, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []*AddOffer{sameBuy}, plan.Add)
+	assert.Equal(t, []*RemoveOffer{{Type: BuyOffer, AssetId: exchangeMosaicId}}, plan.Remove)
+
+	cheaperSell, err := NewAddOffer(SellOffer, newMosaicPanic(exchangeMosaicId, 10), big.NewRat(3, 2), 100)
+	assert.Nil(t, err)
+
+	plan, err = reconcileOffers(state, []*AddOffer{cheaperSell}, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, []*AddOffer{cheaperSell}, plan.Add)
+	assert.Equal(t, []*RemoveOffer{{Type: SellOffer, AssetId: exchangeMosaicId}, {Type: BuyOffer, AssetId: exchangeMosaicId}}, plan.Remove)
+
+	_, err = reconcileOffers(state, []*AddOffer{sameSell, cheaperSell}, 0)
+	assert.Equal(t, ErrInvalidMarketQuote, err)
+}
+
+func TestMarketMaker_DryRun(t *testing.T) {
+	owner, err := NewAccount(PublicTest, &Hash{})
+	assert.Nil(t, err)
+
+	market := NewRecordedMarket(newTestMarketState(50, newTestOffer(SellOffer, 10, 2, 1, 100), newTestOffer(BuyOffer, 10, 1, 1, 100)))
+	strategy := &SpreadStrategy{Spread: big.NewRat(1, 5), Size: 10, MaxInventory: 100, Duration: 100}
+
+	_, err = NewMarketMaker(nil, &MarketMakerConfig{Owner: owner}, strategy, market)
+	assert.Equal(t, ErrInvalidMarketMakerConfig, err)
+
+	maker, err := NewMarketMaker(nil, &MarketMakerConfig{Owner: owner, MosaicId: exchangeMosaicId, DryRun: true}, strategy, market)
+	assert.Nil(t, err)
+
+	plan, err := maker.Step(ctx)
+	assert.Nil(t, err)
+	assert.Len(t, plan.Add, 2)
+	assert.Empty(t, plan.Remove)
+
+	state, err := market.GetMarketState(ctx, owner.PublicAccount, exchangeMosaicId)
+	assert.Nil(t, err)
+	assert.Len(t, state.Offers, 2)
+	assert.Equal(t, Height(110), state.Offers[SellOffer].Deadline)
+	// own offers don't move reference price
+	plan, err = maker.Step(ctx)
+	assert.Nil(t, err)
+	assert.True(t, plan.IsEmpty())
+}
+
+func TestMarketMaker_Step(t *testing.T) {
+	mockServer := newSdkMock(0)
+	defer mockServer.Close()
+
+	announced := 0
+
+	mockServer.AddRouter(&mock.Router{
+		Path:     fmt.Sprintf(offersByMosaicRoute, SellOffer.String(), testExchangeMosaicId.toHexString()),
+		RespBody: testOfferJsonArr,
+	})
+	mockServer.AddRouter(&mock.Router{
+		Path:     blockHeightRoute,
+		RespBody: `{"height": [2074, 0]}`,
+	})
+	mockServer.AddHandler(transactionsRoute, func(resp http.ResponseWriter, req *http.Request) {
+		announced++
+
+		resp.WriteHeader(http.StatusAccepted)
+		fmt.Fprint(resp, `{"message": "packet 9 was pushed to the network via /transaction"}`)
+	})
+	mockServer.AddHandler(strings.TrimSuffix(transactionStatusByIdRoute, "%s"), func(resp http.ResponseWriter, req *http.Request) {
+		hash := req.URL.Path[strings.LastIndex(req.URL.Path, "/")+1:]
+		fmt.Fprintf(resp, `{"group": "confirmed", "status": "Success", "hash": "%s", "deadline": [1, 0], "height": [1, 0]}`, hash)
+	})
+
+	client := mockServer.getPublicTestClientUnsafe()
+	client.config.GenerationHash = &Hash{}
+
+	owner, err := NewAccount(PublicTest, &Hash{})
+	assert.Nil(t, err)
+
+	state, err := client.Exchange.GetMarketState(ctx, owner.PublicAccount, testExchangeMosaicId)
+	assert.Nil(t, err)
+	assert.Equal(t, Height(2074), state.Height)
+	assert.Empty(t, state.Offers)
+	assert.Equal(t, Amount(0), state.Inventory)
+
+	maker, err := NewMarketMaker(client, &MarketMakerConfig{
+		Owner:        owner,
+		MosaicId:     testExchangeMosaicId,
+		PollInterval: time.Millisecond,
+	}, &SpreadStrategy{Spread: big.NewRat(1, 10), Size: 1000, MaxInventory: 1000, Duration: 100}, nil)
+	assert.Nil(t, err)
+
+	// without inventory market maker only buys below the best sell offer
+	plan, err := maker.Step(ctx)
+	assert.Nil(t, err)
+	assert.Len(t, plan.Add, 1)
+	assert.Equal(t, BuyOffer, plan.Add[0].Type)
+	assert.Equal(t, Amount(475), plan.Add[0].Cost)
+	assert.Equal(t, 1, announced)
+}
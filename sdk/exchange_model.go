@@ -6,7 +6,6 @@ package sdk
 
 import (
 	"fmt"
-	"math"
 
 	"github.com/pkg/errors"
 )
@@ -85,21 +84,13 @@ func (info *OfferInfo) String() string {
 	)
 }
 
+// Cost returns exact cost of amount of mosaic in offer, rounded like catapult does on confirmation of offer
 func (info *OfferInfo) Cost(amount Amount) (Amount, error) {
 	if info.Mosaic.Amount < amount {
 		return 0, errors.New("You can't get more mosaics when in offer")
 	}
 
-	switch info.Type {
-	case SellOffer:
-		// If user want to buy mosaic, we round the cost towards the seller(because we buy part of mosaics)
-		return Amount(math.Ceil(float64(info.PriceNumerator) * float64(amount) / float64(info.PriceDenominator))), nil
-	case BuyOffer:
-		// If user want to sell mosaic, we round the cost towards the buyer(because we sell part of mosaics)
-		return Amount(math.Floor(float64(info.PriceNumerator) * float64(amount) / float64(info.PriceDenominator))), nil
-	default:
-		return 0, errors.New("Unknown offer type")
-	}
+	return offerCost(info.Type, amountToBig(info.PriceNumerator), amountToBig(info.PriceDenominator), amount)
 }
 
 func (info *OfferInfo) ConfirmOffer(amount Amount) (*ExchangeConfirmation, error) {
@@ -0,0 +1,165 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"context"
+	"sort"
+
+	"github.com/proximax-storage/go-xpx-utils/str"
+)
+
+// OrderBook is a set of active offers of one mosaic at chain height.
+// Sell offers are ordered from the cheapest one, buy offers are ordered from the most expensive one,
+// so the best offer for counterparty is always the first one
+type OrderBook struct {
+	AssetId AssetId
+	Height  Height
+	Sell    []*OfferInfo
+	Buy     []*OfferInfo
+}
+
+func (b *OrderBook) String() string {
+	return str.StructToString(
+		"OrderBook",
+		str.NewField("AssetId", str.StringPattern, b.AssetId),
+		str.NewField("Height", str.StringPattern, b.Height),
+		str.NewField("Sell", str.StringPattern, b.Sell),
+		str.NewField("Buy", str.StringPattern, b.Buy),
+	)
+}
+
+// NewOrderBook returns order book of offers which are active at height.
+// Offers with deadline not above height, empty offers and offers without price are skipped
+func NewOrderBook(assetId AssetId, height Height, offers ...*OfferInfo) *OrderBook {
+	book := &OrderBook{
+		AssetId: assetId,
+		Height:  height,
+		Sell:    make([]*OfferInfo, 0),
+		Buy:     make([]*OfferInfo, 0),
+	}
+
+	for _, o := range offers {
+		if o == nil || o.Mosaic == nil || o.Mosaic.Amount == 0 || o.PriceDenominator == 0 || o.Deadline <= height {
+			continue
+		}
+
+		switch o.Type {
+		case SellOffer:
+			book.Sell = append(book.Sell, o)
+		case BuyOffer:
+			book.Buy = append(book.Buy, o)
+		}
+	}
+
+	sort.SliceStable(book.Sell, func(i, j int) bool {
+		return compareOfferPrices(book.Sell[i], book.Sell[j]) < 0
+	})
+	sort.SliceStable(book.Buy, func(i, j int) bool {
+		return compareOfferPrices(book.Buy[i], book.Buy[j]) > 0
+	})
+
+	return book
+}
+
+// Offers returns ordered offers of type
+func (b *OrderBook) Offers(offerType OfferType) []*OfferInfo {
+	switch offerType {
+	case SellOffer:
+		return b.Sell
+	case BuyOffer:
+		return b.Buy
+	default:
+		return nil
+	}
+}
+
+// Best returns the best offer of type or nil when there are no such offers
+func (b *OrderBook) Best(offerType OfferType) *OfferInfo {
+	offers := b.Offers(offerType)
+	if len(offers) == 0 {
+		return nil
+	}
+
+	return offers[0]
+}
+
+// Depth returns total amount of mosaic available in offers of type
+func (b *OrderBook) Depth(offerType OfferType) Amount {
+	var depth Amount
+	for _, o := range b.Offers(offerType) {
+		depth += o.Mosaic.Amount
+	}
+
+	return depth
+}
+
+// FillPlan returns confirmations of offers of type which exchange amount of mosaic at the best price.
+// To buy mosaic, sell offers are confirmed from the cheapest one, to sell mosaic, buy offers are confirmed
+// from the most expensive one. Confirmations can be passed to NewExchangeOfferTransaction
+func (b *OrderBook) FillPlan(offerType OfferType, amount Amount) ([]*ExchangeConfirmation, error) {
+	if offerType != SellOffer && offerType != BuyOffer {
+		return nil, ErrUnknownOfferType
+	}
+
+	if amount <= 0 {
+		return nil, ErrInvalidFillAmount
+	}
+
+	if b.Depth(offerType) < amount {
+		return nil, ErrInsufficientLiquidity
+	}
+
+	confirmations := make([]*ExchangeConfirmation, 0)
+	for _, o := range b.Offers(offerType) {
+		if amount == 0 {
+			break
+		}
+
+		part := o.Mosaic.Amount
+		if part > amount {
+			part = amount
+		}
+
+		c, err := o.ConfirmOffer(part)
+		if err != nil {
+			return nil, err
+		}
+
+		confirmations = append(confirmations, c)
+		amount -= part
+	}
+
+	return confirmations, nil
+}
+
+// compareOfferPrices compares exact prices of offers, returns -1, 0 or 1 like big.Rat.Cmp
+func compareOfferPrices(a, b *OfferInfo) int {
+	return a.Price().Cmp(b.Price())
+}
+
+// GetOrderBook returns order book of active sell and buy offers of asset at current chain height
+func (e *ExchangeService) GetOrderBook(ctx context.Context, assetId AssetId) (*OrderBook, error) {
+	if assetId == nil {
+		return nil, ErrNilAssetId
+	}
+
+	height, err := e.client.Blockchain.GetBlockchainHeight(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	offers := make([]*OfferInfo, 0)
+	for _, offerType := range []OfferType{SellOffer, BuyOffer} {
+		infos, err := e.GetExchangeOfferByAssetId(ctx, assetId, offerType)
+		if err != nil && !isNotFoundError(err) {
+			return nil, err
+		}
+
+		offers = append(offers, infos...)
+	}
+
+	return NewOrderBook(assetId, height, offers...), nil
+}
@@ -0,0 +1,98 @@
+package sdk
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/proximax-storage/go-xpx-utils/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestOffer(offerType OfferType, amount, numerator, denominator Amount, deadline Height) *OfferInfo {
+	return &OfferInfo{
+		Type:             offerType,
+		Owner:            exchangeAccount,
+		Mosaic:           newMosaicPanic(exchangeMosaicId, amount),
+		PriceNumerator:   numerator,
+		PriceDenominator: denominator,
+		Deadline:         deadline,
+	}
+}
+
+func TestNewOrderBook(t *testing.T) {
+	cheap := newTestOffer(SellOffer, 10, 1, 2, 100)
+	expensive := newTestOffer(SellOffer, 10, 2, 1, 100)
+	middle := newTestOffer(SellOffer, 10, 3, 3, 100)
+	expired := newTestOffer(SellOffer, 10, 1, 10, 50)
+	empty := newTestOffer(SellOffer, 0, 1, 10, 100)
+	lowBid := newTestOffer(BuyOffer, 10, 1, 3, 100)
+	highBid := newTestOffer(BuyOffer, 10, 2, 3, 100)
+
+	book := NewOrderBook(exchangeMosaicId, 50, expensive, lowBid, cheap, expired, middle, empty, highBid)
+	assert.Equal(t, []*OfferInfo{cheap, middle, expensive}, book.Sell)
+	assert.Equal(t, []*OfferInfo{highBid, lowBid}, book.Buy)
+	assert.Equal(t, cheap, book.Best(SellOffer))
+	assert.Equal(t, highBid, book.Best(BuyOffer))
+	assert.Equal(t, Amount(30), book.Depth(SellOffer))
+	assert.Nil(t, book.Best(UnknownType))
+}
+
+func TestOrderBook_FillPlan(t *testing.T) {
+	book := NewOrderBook(exchangeMosaicId, 1,
+		newTestOffer(SellOffer, 10, 2, 1, 100),
+		newTestOffer(SellOffer, 5, 1, 2, 100),
+		newTestOffer(BuyOffer, 4, 1, 3, 100),
+	)
+
+	plan, err := book.FillPlan(SellOffer, 12)
+	assert.Nil(t, err)
+	assert.Len(t, plan, 2)
+	assert.Equal(t, newMosaicPanic(exchangeMosaicId, 5), plan[0].Mosaic)
+	// cost of sell offer is rounded up
+	assert.Equal(t, Amount(3), plan[0].Cost)
+	assert.Equal(t, newMosaicPanic(exchangeMosaicId, 7), plan[1].Mosaic)
+	assert.Equal(t, Amount(14), plan[1].Cost)
+
+	plan, err = book.FillPlan(BuyOffer, 4)
+	assert.Nil(t, err)
+	assert.Len(t, plan, 1)
+	// cost of buy offer is rounded down
+	assert.Equal(t, Amount(1), plan[0].Cost)
+
+	tx, err := NewExchangeOfferTransaction(NewDeadline(time.Hour), plan, PublicTest)
+	assert.Nil(t, err)
+	assert.Equal(t, plan, tx.Confirmations)
+
+	_, err = book.FillPlan(BuyOffer, 5)
+	assert.Equal(t, ErrInsufficientLiquidity, err)
+
+	_, err = book.FillPlan(UnknownType, 5)
+	assert.Equal(t, ErrUnknownOfferType, err)
+
+	_, err = book.FillPlan(SellOffer, 0)
+	assert.Equal(t, ErrInvalidFillAmount, err)
+
+	_, err = book.FillPlan(BuyOffer, -5)
+	assert.Equal(t, ErrInvalidFillAmount, err)
+}
+
+func TestExchangeService_GetOrderBook(t *testing.T) {
+	mockServer := newSdkMock(0)
+	defer mockServer.Close()
+
+	mockServer.AddRouter(&mock.Router{
+		Path:     fmt.Sprintf(offersByMosaicRoute, SellOffer.String(), testExchangeMosaicId.toHexString()),
+		RespBody: testOfferJsonArr,
+	})
+	mockServer.AddRouter(&mock.Router{
+		Path:     blockHeightRoute,
+		RespBody: `{"height": [2074, 0]}`,
+	})
+
+	book, err := mockServer.getPublicTestClientUnsafe().Exchange.GetOrderBook(ctx, testExchangeMosaicId)
+	assert.Nil(t, err)
+	assert.Equal(t, Height(2074), book.Height)
+	assert.Equal(t, []*OfferInfo{testOfferInfo, testOfferInfo}, book.Sell)
+	assert.Empty(t, book.Buy)
+}
@@ -0,0 +1,86 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"math/big"
+)
+
+// NewOfferPrice returns exact price of one unit of mosaic when amount of mosaic costs cost
+func NewOfferPrice(cost, amount Amount) (*big.Rat, error) {
+	if cost < 0 || amount <= 0 {
+		return nil, ErrInvalidOfferPrice
+	}
+
+	return new(big.Rat).SetFrac(amountToBig(cost), amountToBig(amount)), nil
+}
+
+// Price returns exact price of one unit of mosaic in offer or nil when offer doesn't have price
+func (info *OfferInfo) Price() *big.Rat {
+	if info.PriceDenominator == 0 {
+		return nil
+	}
+
+	return new(big.Rat).SetFrac(amountToBig(info.PriceNumerator), amountToBig(info.PriceDenominator))
+}
+
+// NewAddOffer returns offer of mosaic at price, cost of the whole mosaic amount is rounded like on confirmation of offer
+func NewAddOffer(offerType OfferType, mosaic *Mosaic, price *big.Rat, duration Duration) (*AddOffer, error) {
+	if mosaic == nil {
+		return nil, ErrNilMosaic
+	}
+
+	if price == nil {
+		return nil, ErrInvalidOfferPrice
+	}
+
+	cost, err := offerCost(offerType, price.Num(), price.Denom(), mosaic.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AddOffer{
+		Offer: Offer{
+			Type:   offerType,
+			Mosaic: mosaic,
+			Cost:   cost,
+		},
+		Duration: duration,
+	}, nil
+}
+
+// offerCost returns numerator * amount / denominator without intermediate overflow.
+// Like catapult, cost of sell offer is rounded up in favor of seller and cost of buy offer is rounded down in favor of buyer
+func offerCost(offerType OfferType, numerator, denominator *big.Int, amount Amount) (Amount, error) {
+	if numerator.Sign() < 0 || denominator.Sign() <= 0 {
+		return 0, ErrInvalidOfferPrice
+	}
+
+	if amount < 0 {
+		return 0, ErrInvalidOfferAmount
+	}
+
+	cost, rem := new(big.Int).QuoRem(new(big.Int).Mul(numerator, amountToBig(amount)), denominator, new(big.Int))
+
+	switch offerType {
+	case SellOffer:
+		if rem.Sign() > 0 {
+			cost.Add(cost, big.NewInt(1))
+		}
+	case BuyOffer:
+	default:
+		return 0, ErrUnknownOfferType
+	}
+
+	if !cost.IsInt64() {
+		return 0, ErrOfferCostOverflow
+	}
+
+	return Amount(cost.Int64()), nil
+}
+
+func amountToBig(amount Amount) *big.Int {
+	return big.NewInt(int64(amount))
+}
@@ -0,0 +1,106 @@
+package sdk
+
+import (
+	"math"
+	"math/big"
+	"math/bits"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// catapultOfferCost is a reference of catapult rounding with 128 bit integers, ok is false when cost overflows
+func catapultOfferCost(offerType OfferType, numerator, denominator, amount uint64) (cost uint64, ok bool) {
+	hi, lo := bits.Mul64(numerator, amount)
+	if hi >= denominator {
+		return 0, false
+	}
+
+	cost, rem := bits.Div64(hi, lo, denominator)
+	if offerType == SellOffer && rem > 0 {
+		cost++
+	}
+
+	// amounts of SDK are signed
+	return cost, cost <= math.MaxInt64
+}
+
+func TestOfferCost_MatchesCatapult(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	// values are spread over all bit lengths below 2^63
+	random := func() uint64 {
+		return uint64(r.Int63()>>uint(r.Intn(63))) | 1
+	}
+
+	for i := 0; i < 100000; i++ {
+		numerator, denominator, amount := random(), random(), random()
+		offerType := OfferType(i % 2)
+
+		expected, ok := catapultOfferCost(offerType, numerator, denominator, amount)
+		cost, err := offerCost(offerType, new(big.Int).SetUint64(numerator), new(big.Int).SetUint64(denominator), Amount(amount))
+		if !ok {
+			assert.Equal(t, ErrOfferCostOverflow, err, "%d * %d / %d", numerator, amount, denominator)
+			continue
+		}
+
+		if !assert.Nil(t, err) || !assert.Equal(t, Amount(expected), cost, "%s %d * %d / %d", offerType, numerator, amount, denominator) {
+			return
+		}
+	}
+}
+
+func TestOfferCost_LargeAmounts(t *testing.T) {
+	offer := &OfferInfo{
+		Type:             SellOffer,
+		Mosaic:           newMosaicPanic(exchangeMosaicId, Amount(math.MaxInt64)),
+		PriceNumerator:   Amount(1<<63 - 1),
+		PriceDenominator: Amount(1<<63 - 2),
+	}
+
+	// float64 rounds both values to 2^63 and loses the extra unit
+	cost, err := offer.Cost(Amount(1<<63 - 2))
+	assert.Nil(t, err)
+	assert.Equal(t, Amount(1<<63-1), cost)
+
+	offer.Type = BuyOffer
+	cost, err = offer.Cost(Amount(1<<63 - 3))
+	assert.Nil(t, err)
+	assert.Equal(t, Amount(1<<63-3), cost)
+
+	offer.PriceNumerator, offer.PriceDenominator = 4, 1
+	_, err = offer.Cost(Amount(1 << 62))
+	assert.Equal(t, ErrOfferCostOverflow, err)
+
+	_, err = offer.Cost(-1)
+	assert.Equal(t, ErrInvalidOfferAmount, err)
+
+	offer.PriceDenominator = 0
+	_, err = offer.Cost(1)
+	assert.Equal(t, ErrInvalidOfferPrice, err)
+	assert.Nil(t, offer.Price())
+}
+
+func TestNewAddOffer(t *testing.T) {
+	price, err := NewOfferPrice(1, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewRat(1, 3), price)
+
+	offer, err := NewAddOffer(SellOffer, newMosaicPanic(exchangeMosaicId, 100), price, 1000)
+	assert.Nil(t, err)
+	assert.Equal(t, Amount(34), offer.Cost)
+	assert.Equal(t, Duration(1000), offer.Duration)
+
+	offer, err = NewAddOffer(BuyOffer, newMosaicPanic(exchangeMosaicId, 100), price, 1000)
+	assert.Nil(t, err)
+	assert.Equal(t, Amount(33), offer.Cost)
+
+	_, err = NewAddOffer(UnknownType, newMosaicPanic(exchangeMosaicId, 100), price, 1000)
+	assert.Equal(t, ErrUnknownOfferType, err)
+
+	_, err = NewAddOffer(SellOffer, newMosaicPanic(exchangeMosaicId, 100), big.NewRat(-1, 3), 1000)
+	assert.Equal(t, ErrInvalidOfferPrice, err)
+
+	_, err = NewOfferPrice(1, 0)
+	assert.Equal(t, ErrInvalidOfferPrice, err)
+}
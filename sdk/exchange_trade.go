@@ -0,0 +1,148 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"context"
+	"sort"
+)
+
+// GetTradeHistory returns fills of offers of owner confirmed within heights range, zero to means current height.
+// Exchange transactions are signed by counterparties, so every exchange transaction of range is scanned
+// including ones inside aggregate transactions. Node keeps only current exchange info of owner, so fills are
+// reconciled with it only when range ends at current height, see TradeHistory.Reconstructed
+func (e *ExchangeService) GetTradeHistory(ctx context.Context, owner *PublicAccount, from, to Height) (*TradeHistory, error) {
+	if owner == nil {
+		return nil, ErrNilAccount
+	}
+
+	it := e.client.Transaction.IterateTransactionsByGroup(ctx, Confirmed, &TransactionsPageOptions{
+		FromHeight: uint64(from),
+		ToHeight:   uint64(to),
+		Type:       []uint{uint(ExchangeOffer), uint(AggregateCompleted), uint(AggregateBonded)},
+	}, nil)
+	defer it.Close()
+
+	history := &TradeHistory{
+		Owner: owner,
+		From:  from,
+		To:    to,
+		Fills: make([]*ExchangeFill, 0),
+	}
+
+	for it.Next() {
+		tx := it.Transaction()
+		atx := tx.GetAbstractTransaction()
+
+		exchangeTxs := make([]*ExchangeOfferTransaction, 0, 1)
+		switch tx := tx.(type) {
+		case *ExchangeOfferTransaction:
+			exchangeTxs = append(exchangeTxs, tx)
+		case *AggregateTransaction:
+			for _, inner := range tx.InnerTransactions {
+				if exchangeTx, ok := inner.(*ExchangeOfferTransaction); ok {
+					exchangeTxs = append(exchangeTxs, exchangeTx)
+				}
+			}
+		}
+
+		for _, exchangeTx := range exchangeTxs {
+			for _, c := range exchangeTx.Confirmations {
+				if c.Owner == nil || c.Owner.PublicKey != owner.PublicKey {
+					continue
+				}
+
+				history.Fills = append(history.Fills, &ExchangeFill{
+					Height:          atx.Height,
+					TransactionHash: atx.TransactionHash,
+					Counterparty:    exchangeTx.Signer,
+					Type:            c.Type,
+					Mosaic:          c.Mosaic,
+					Cost:            c.Cost,
+				})
+			}
+		}
+	}
+
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(history.Fills, func(i, j int) bool {
+		return history.Fills[i].Height < history.Fills[j].Height
+	})
+
+	if to != 0 {
+		height, err := e.client.Blockchain.GetBlockchainHeight(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if to < height {
+			return history, nil
+		}
+	}
+
+	snapshot, err := e.GetAccountExchangeInfo(ctx, owner)
+	if err != nil && !isNotFoundError(err) {
+		return nil, err
+	}
+
+	starts, err := e.offerStarts(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	reconstructFills(history.Fills, snapshot, starts)
+	history.Reconstructed = true
+
+	return history, nil
+}
+
+// offerStarts returns heights of the latest AddExchangeOfferTransaction of owner by type and mosaic of offer
+func (e *ExchangeService) offerStarts(ctx context.Context, owner *PublicAccount) (map[offerDepositKey]Height, error) {
+	starts := make(map[offerDepositKey]Height)
+
+	it := e.client.Transaction.IterateTransactionsByGroup(ctx, Confirmed, &TransactionsPageOptions{
+		Address:  owner.Address.Address,
+		Type:     []uint{uint(AddExchangeOffer)},
+		Embedded: true,
+	}, nil)
+	defer it.Close()
+
+	for it.Next() {
+		tx := it.Transaction()
+		height := tx.GetAbstractTransaction().Height
+
+		inner := []Transaction{tx}
+		if aggTx, ok := tx.(*AggregateTransaction); ok {
+			inner = aggTx.InnerTransactions
+		}
+
+		for _, innerTx := range inner {
+			addTx, ok := innerTx.(*AddExchangeOfferTransaction)
+			if !ok || addTx.Signer == nil || addTx.Signer.PublicKey != owner.PublicKey {
+				continue
+			}
+
+			for _, o := range addTx.Offers {
+				if o.Mosaic == nil || o.Mosaic.AssetId == nil {
+					continue
+				}
+
+				key := offerDepositKey{owner.PublicKey, o.Type, o.Mosaic.AssetId.Id()}
+				if height > starts[key] {
+					starts[key] = height
+				}
+			}
+		}
+	}
+
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	return starts, nil
+}
@@ -0,0 +1,163 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"math/big"
+	"sort"
+
+	"github.com/proximax-storage/go-xpx-utils/str"
+)
+
+// ExchangeFill is a confirmation of owner offer by counterparty
+type ExchangeFill struct {
+	Height Height
+	// TransactionHash is a hash of exchange transaction or of aggregate transaction which contains it
+	TransactionHash *Hash
+	Counterparty    *PublicAccount
+	// Type is a type of owner offer, fill of sell offer means that owner sold mosaic
+	Type   OfferType
+	Mosaic *Mosaic
+	Cost   Amount
+	// Active is true when fill belongs to offer which is still active in exchange snapshot,
+	// only then Remaining is an amount of mosaic left in offer after fill. Both are set only for reconstructed history
+	Active    bool
+	Remaining Amount
+}
+
+func (f *ExchangeFill) String() string {
+	return str.StructToString(
+		"ExchangeFill",
+		str.NewField("Height", str.StringPattern, f.Height),
+		str.NewField("TransactionHash", str.StringPattern, f.TransactionHash),
+		str.NewField("Counterparty", str.StringPattern, f.Counterparty),
+		str.NewField("Type", str.StringPattern, f.Type),
+		str.NewField("Mosaic", str.StringPattern, f.Mosaic),
+		str.NewField("Cost", str.StringPattern, f.Cost),
+		str.NewField("Active", str.BooleanPattern, f.Active),
+		str.NewField("Remaining", str.StringPattern, f.Remaining),
+	)
+}
+
+// Price returns exact price of one unit of mosaic paid in fill
+func (f *ExchangeFill) Price() *big.Rat {
+	price, _ := NewOfferPrice(f.Cost, f.Mosaic.Amount)
+	return price
+}
+
+// TradeSummary is a volume of trades of owner in one mosaic
+type TradeSummary struct {
+	AssetId AssetId
+	Fills   int
+	// Sold is an amount of mosaic sold by sell offers of owner for Received
+	Sold     Amount
+	Received Amount
+	// Bought is an amount of mosaic bought by buy offers of owner for Paid
+	Bought Amount
+	Paid   Amount
+}
+
+func (s *TradeSummary) String() string {
+	return str.StructToString(
+		"TradeSummary",
+		str.NewField("AssetId", str.StringPattern, s.AssetId),
+		str.NewField("Fills", str.IntPattern, s.Fills),
+		str.NewField("Sold", str.StringPattern, s.Sold),
+		str.NewField("Received", str.StringPattern, s.Received),
+		str.NewField("Bought", str.StringPattern, s.Bought),
+		str.NewField("Paid", str.StringPattern, s.Paid),
+	)
+}
+
+// AverageSellPrice returns volume weighted price of sold mosaic or nil when nothing was sold
+func (s *TradeSummary) AverageSellPrice() *big.Rat {
+	price, _ := NewOfferPrice(s.Received, s.Sold)
+	return price
+}
+
+// AverageBuyPrice returns volume weighted price of bought mosaic or nil when nothing was bought
+func (s *TradeSummary) AverageBuyPrice() *big.Rat {
+	price, _ := NewOfferPrice(s.Paid, s.Bought)
+	return price
+}
+
+// TradeHistory is a list of fills of owner offers within heights range, fills are ordered by height
+type TradeHistory struct {
+	Owner *PublicAccount
+	From  Height
+	To    Height
+	Fills []*ExchangeFill
+	// Reconstructed is true when range ends at current height, so Active and Remaining of fills are restored
+	// from current exchange info of owner. Past ranges keep them unset, since node doesn't store past offers
+	Reconstructed bool
+}
+
+// Summaries returns volume of trades of every mosaic ordered by mosaic id
+func (h *TradeHistory) Summaries() []*TradeSummary {
+	byId := make(map[uint64]*TradeSummary)
+	summaries := make([]*TradeSummary, 0)
+
+	for _, f := range h.Fills {
+		s, ok := byId[f.Mosaic.AssetId.Id()]
+		if !ok {
+			s = &TradeSummary{AssetId: f.Mosaic.AssetId}
+			byId[f.Mosaic.AssetId.Id()] = s
+			summaries = append(summaries, s)
+		}
+
+		s.Fills++
+
+		switch f.Type {
+		case SellOffer:
+			s.Sold += f.Mosaic.Amount
+			s.Received += f.Cost
+		case BuyOffer:
+			s.Bought += f.Mosaic.Amount
+			s.Paid += f.Cost
+		}
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].AssetId.Id() < summaries[j].AssetId.Id()
+	})
+
+	return summaries
+}
+
+// reconstructFills marks fills of offers which are active in snapshot and restores amount left in them after every fill.
+// Fills are walked from the latest one down to height where active offer was added, starts are heights of
+// the latest added offers by type and mosaic. Older fills belong to replaced offers even with the same price
+func reconstructFills(fills []*ExchangeFill, snapshot *UserExchangeInfo, starts map[offerDepositKey]Height) {
+	if snapshot == nil {
+		return
+	}
+
+	for offerType, offers := range snapshot.Offers {
+		for _, offer := range offers {
+			remaining := offer.Mosaic.Amount
+			start := starts[offerDepositKey{snapshot.Owner.PublicKey, offerType, offer.Mosaic.AssetId.Id()}]
+
+			for i := len(fills) - 1; i >= 0; i-- {
+				f := fills[i]
+				if f.Type != offerType || f.Mosaic.AssetId.Id() != offer.Mosaic.AssetId.Id() {
+					continue
+				}
+
+				if f.Height < start {
+					break
+				}
+
+				cost, err := offerCost(offerType, amountToBig(offer.PriceNumerator), amountToBig(offer.PriceDenominator), f.Mosaic.Amount)
+				if err != nil || cost != f.Cost {
+					break
+				}
+
+				f.Active = true
+				f.Remaining = remaining
+				remaining += f.Mosaic.Amount
+			}
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package sdk
+
+import (
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testExchangeCounterparty, _ = NewAccountFromPublicKey("9A49366406ACA952B88BADF5F1E9BE6CE4968141035A60BE503273EA65456B24", PublicTest)
+
+func testExchangeOfferJson(owner *PublicAccount, offerType OfferType, amount, cost int) string {
+	return fmt.Sprintf(`{"mosaicId": [519256100, 642862634], "mosaicAmount": [%d, 0], "cost": [%d, 0], "type": %d, "owner": "%s"}`,
+		amount, cost, offerType, owner.PublicKey)
+}
+
+func testExchangeTransactionJson(height int, offers ...string) string {
+	return fmt.Sprintf(`{
+		"meta": {"height": [%d, 0], "hash": "%064X", "merkleComponentHash": "%064X", "index": 0, "id": "5B686E97F0C0EA00017B9437"},
+		"transaction": {
+			"signature": "%0128X",
+			"signer": "%s",
+			"version": -1879048191,
+			"type": 16989,
+			"maxFee": [0, 0],
+			"deadline": [1094650402, 17],
+			"offers": [%s]
+		}
+	}`, height, height, height, 0, testExchangeCounterparty.PublicKey, strings.Join(offers, ", "))
+}
+
+func testExchangeAggregateJson(height int, offers ...string) string {
+	return fmt.Sprintf(`{
+		"meta": {"height": [%d, 0], "hash": "%064X", "merkleComponentHash": "%064X", "index": 0, "id": "5B686E97F0C0EA00017B9438"},
+		"transaction": {
+			"signature": "%0128X",
+			"signer": "%s",
+			"version": -1879048189,
+			"type": 16705,
+			"maxFee": [0, 0],
+			"deadline": [1094650402, 17],
+			"cosignatures": [],
+			"transactions": [{
+				"meta": {"height": [%d, 0], "aggregateHash": "%064X", "aggregateId": "5B686E97F0C0EA00017B9438", "id": "5B686E97F0C0EA00017B9439", "index": 0},
+				"transaction": {
+					"signer": "%s",
+					"version": -1879048191,
+					"type": 16989,
+					"offers": [%s]
+				}
+			}]
+		}
+	}`, height, height, height, 0, testExchangeCounterparty.PublicKey, height, height, testExchangeCounterparty.PublicKey, strings.Join(offers, ", "))
+}
+
+func testAddExchangeOfferJson(height int, owner *PublicAccount, offerType OfferType, amount, cost int) string {
+	return fmt.Sprintf(`{
+		"meta": {"height": [%d, 0], "hash": "%064X", "merkleComponentHash": "%064X", "index": 0, "id": "5B686E97F0C0EA00017B9440"},
+		"transaction": {
+			"signature": "%0128X",
+			"signer": "%s",
+			"version": -1879048188,
+			"type": 16733,
+			"maxFee": [0, 0],
+			"deadline": [1094650402, 17],
+			"offers": [{"mosaicId": [519256100, 642862634], "mosaicAmount": [%d, 0], "cost": [%d, 0], "type": %d, "duration": [1000, 0]}]
+		}
+	}`, height, height, height, 0, owner.PublicKey, amount, cost, offerType)
+}
+
+func TestTradeHistory_Summaries(t *testing.T) {
+	history := &TradeHistory{Fills: []*ExchangeFill{
+		{Type: SellOffer, Mosaic: newMosaicPanic(exchangeMosaicId, 10), Cost: 5},
+		{Type: SellOffer, Mosaic: newMosaicPanic(exchangeMosaicId, 30), Cost: 25},
+		{Type: BuyOffer, Mosaic: newMosaicPanic(exchangeMosaicId, 20), Cost: 10},
+	}}
+
+	summaries := history.Summaries()
+	assert.Len(t, summaries, 1)
+	assert.Equal(t, 3, summaries[0].Fills)
+	assert.Equal(t, Amount(40), summaries[0].Sold)
+	assert.Equal(t, Amount(30), summaries[0].Received)
+	assert.Equal(t, big.NewRat(3, 4), summaries[0].AverageSellPrice())
+	assert.Equal(t, big.NewRat(1, 2), summaries[0].AverageBuyPrice())
+	assert.Equal(t, big.NewRat(5, 6), history.Fills[1].Price())
+
+	assert.Nil(t, (&TradeSummary{}).AverageSellPrice())
+}
+
+func TestExchangeService_GetTradeHistory(t *testing.T) {
+	mock := newSdkMock(0)
+	defer mock.Close()
+
+	page := []string{
+		testExchangeTransactionJson(10, testExchangeOfferJson(testExchangeAccount, SellOffer, 1000, 500)),
+		// aggregate fills offers of owner and of another account
+		testExchangeAggregateJson(15,
+			testExchangeOfferJson(testExchangeAccount, BuyOffer, 100, 40),
+			testExchangeOfferJson(testExchangeCounterparty, SellOffer, 100, 50),
+		),
+		testExchangeTransactionJson(20, testExchangeOfferJson(testExchangeAccount, SellOffer, 1350, 675)),
+	}
+
+	// sell offer filled at height 10 was replaced at height 12 by offer with the same price
+	adds := []string{
+		testAddExchangeOfferJson(8, testExchangeAccount, SellOffer, 2000, 1000),
+		testAddExchangeOfferJson(12, testExchangeAccount, SellOffer, 1000000, 500000),
+	}
+
+	mock.AddHandler(fmt.Sprintf(transactionsByGroupRoute, Confirmed), func(resp http.ResponseWriter, req *http.Request) {
+		if req.URL.Query().Get("type[]") == fmt.Sprint(uint(AddExchangeOffer)) {
+			assert.Equal(t, testExchangeAccount.Address.Address, req.URL.Query().Get("address"))
+
+			fmt.Fprintf(resp, `{"data": [%s], "pagination": {"totalEntries": 2, "pageNumber": 1, "pageSize": 20, "totalPages": 1}}`, strings.Join(adds, ", "))
+			return
+		}
+
+		assert.Equal(t, "5", req.URL.Query().Get("fromHeight"))
+		assert.Len(t, req.URL.Query()["type[]"], 3)
+
+		fmt.Fprintf(resp, `{"data": [%s], "pagination": {"totalEntries": 3, "pageNumber": 1, "pageSize": 20, "totalPages": 1}}`, strings.Join(page, ", "))
+	})
+	mock.AddHandler(blockHeightRoute, func(resp http.ResponseWriter, req *http.Request) {
+		fmt.Fprint(resp, `{"height": [30, 0]}`)
+	})
+	mock.AddHandler(fmt.Sprintf(exchangeRoute, testExchangeAccount.PublicKey), func(resp http.ResponseWriter, req *http.Request) {
+		fmt.Fprint(resp, testAccountExchangeInfoJson)
+	})
+
+	client := mock.getPublicTestClientUnsafe()
+	client.config.GenerationHash = &Hash{}
+
+	history, err := client.Exchange.GetTradeHistory(ctx, testExchangeAccount, 5, 0)
+	assert.Nil(t, err)
+	assert.True(t, history.Reconstructed)
+	assert.Len(t, history.Fills, 3)
+
+	first, second, third := history.Fills[0], history.Fills[1], history.Fills[2]
+	assert.Equal(t, Height(10), first.Height)
+	assert.Equal(t, testExchangeCounterparty.PublicKey, first.Counterparty.PublicKey)
+	assert.Equal(t, Height(15), second.Height)
+	assert.Equal(t, BuyOffer, second.Type)
+
+	// sell offer of snapshot has 997650 units left, the first fill belongs to replaced offer
+	assert.True(t, third.Active)
+	assert.Equal(t, Amount(997650), third.Remaining)
+	assert.False(t, first.Active)
+	assert.False(t, second.Active)
+
+	summaries := history.Summaries()
+	assert.Len(t, summaries, 1)
+	assert.Equal(t, Amount(2350), summaries[0].Sold)
+	assert.Equal(t, big.NewRat(1, 2), summaries[0].AverageSellPrice())
+	assert.Equal(t, Amount(100), summaries[0].Bought)
+
+	// exchange info of owner is current, so past range isn't reconstructed
+	past, err := client.Exchange.GetTradeHistory(ctx, testExchangeAccount, 5, 20)
+	assert.Nil(t, err)
+	assert.False(t, past.Reconstructed)
+	assert.Len(t, past.Fills, 3)
+	assert.False(t, past.Fills[2].Active)
+}
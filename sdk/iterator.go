@@ -0,0 +1,378 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DefaultIteratorPrefetch is a count of pages which iterator requests in background by default
+const DefaultIteratorPrefetch = 1
+
+// IteratorToken is a position of the next item of paged endpoint.
+// Token stays valid only while page size and filters of request are the same
+type IteratorToken struct {
+	PageNumber uint64
+	Index      int
+}
+
+func (t *IteratorToken) String() string {
+	return fmt.Sprintf("%d.%d", t.PageNumber, t.Index)
+}
+
+// ParseIteratorToken parses token returned by IteratorToken.String
+func ParseIteratorToken(token string) (*IteratorToken, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return nil, ErrInvalidIteratorToken
+	}
+
+	page, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil || page == 0 {
+		return nil, ErrInvalidIteratorToken
+	}
+
+	index, err := strconv.Atoi(parts[1])
+	if err != nil || index < 0 {
+		return nil, ErrInvalidIteratorToken
+	}
+
+	return &IteratorToken{PageNumber: page, Index: index}, nil
+}
+
+// IteratorOptions are options of iterator over paged endpoint
+type IteratorOptions struct {
+	// Prefetch is a count of pages requested in background while current page is consumed,
+	// zero requests pages only when they are needed. Negative value means DefaultIteratorPrefetch
+	Prefetch int
+	// Token is a position where iteration starts, nil starts from the first page
+	Token *IteratorToken
+}
+
+type iteratorPage struct {
+	number     uint64
+	items      []interface{}
+	pagination Pagination
+	err        error
+}
+
+// pageFetcher requests page of endpoint by its number
+type pageFetcher func(ctx context.Context, pageNumber uint64) ([]interface{}, Pagination, error)
+
+// pageIterator walks every page of endpoint lazily, pages are requested on first call of next
+type pageIterator struct {
+	ctx      context.Context
+	cancel   context.CancelFunc
+	fetch    pageFetcher
+	prefetch int
+	start    IteratorToken
+
+	pages   chan *iteratorPage
+	started bool
+	done    bool
+	err     error
+
+	current *iteratorPage
+	index   int
+}
+
+func newPageIterator(ctx context.Context, fetch pageFetcher, opts *IteratorOptions) *pageIterator {
+	ctx, cancel := context.WithCancel(ctx)
+
+	it := &pageIterator{
+		ctx:      ctx,
+		cancel:   cancel,
+		fetch:    fetch,
+		prefetch: DefaultIteratorPrefetch,
+		start:    IteratorToken{PageNumber: 1},
+	}
+
+	if opts != nil {
+		if opts.Prefetch >= 0 {
+			it.prefetch = opts.Prefetch
+		}
+
+		if opts.Token != nil {
+			it.start = *opts.Token
+		}
+	}
+
+	return it
+}
+
+// run requests pages in background, goroutine holds one page while waiting for free place in channel
+func (it *pageIterator) run() {
+	defer close(it.pages)
+
+	for number := it.start.PageNumber; ; number++ {
+		page := it.fetchPage(number)
+
+		select {
+		case it.pages <- page:
+		case <-it.ctx.Done():
+			return
+		}
+
+		if it.isLast(page) {
+			return
+		}
+	}
+}
+
+func (it *pageIterator) fetchPage(number uint64) *iteratorPage {
+	items, pagination, err := it.fetch(it.ctx, number)
+
+	return &iteratorPage{number: number, items: items, pagination: pagination, err: err}
+}
+
+func (it *pageIterator) isLast(page *iteratorPage) bool {
+	return page.err != nil || len(page.items) == 0 || page.number >= page.pagination.TotalPages
+}
+
+func (it *pageIterator) nextPage() (*iteratorPage, bool) {
+	if it.prefetch == 0 {
+		if it.current != nil && it.isLast(it.current) {
+			return nil, false
+		}
+
+		number := it.start.PageNumber
+		if it.current != nil {
+			number = it.current.number + 1
+		}
+
+		return it.fetchPage(number), true
+	}
+
+	if !it.started {
+		it.started = true
+		it.pages = make(chan *iteratorPage, it.prefetch-1)
+		go it.run()
+	}
+
+	select {
+	case page, ok := <-it.pages:
+		return page, ok
+	case <-it.ctx.Done():
+		return nil, false
+	}
+}
+
+// next returns the next item, false is returned when items are over or iteration failed
+func (it *pageIterator) next() (interface{}, bool) {
+	for !it.done {
+		if err := it.ctx.Err(); err != nil {
+			it.fail(err)
+			break
+		}
+
+		if it.current != nil && it.index < len(it.current.items) {
+			item := it.current.items[it.index]
+			it.index++
+			return item, true
+		}
+
+		page, ok := it.nextPage()
+		if !ok {
+			it.fail(it.ctx.Err())
+			break
+		}
+
+		if page.err != nil {
+			it.fail(page.err)
+			break
+		}
+
+		it.index = 0
+		if it.current == nil {
+			it.index = it.start.Index
+		}
+
+		it.current = page
+	}
+
+	return nil, false
+}
+
+func (it *pageIterator) fail(err error) {
+	it.done = true
+	it.err = err
+	it.cancel()
+}
+
+// token returns position of the next item
+func (it *pageIterator) token() *IteratorToken {
+	if it.current == nil {
+		token := it.start
+		return &token
+	}
+
+	if it.index < len(it.current.items) {
+		return &IteratorToken{PageNumber: it.current.number, Index: it.index}
+	}
+
+	return &IteratorToken{PageNumber: it.current.number + 1}
+}
+
+func (it *pageIterator) close() {
+	if !it.done {
+		it.done = true
+		it.cancel()
+	}
+}
+
+// collect calls fn for items until max of them is collected, zero max collects every item
+func (it *pageIterator) collect(max int, fn func(interface{})) error {
+	for count := 0; max <= 0 || count < max; count++ {
+		item, ok := it.next()
+		if !ok {
+			break
+		}
+
+		fn(item)
+	}
+
+	return it.err
+}
+
+// DriveIterator walks drives of every page of StorageService.GetDrives
+type DriveIterator struct {
+	it    *pageIterator
+	drive *Drive
+}
+
+// Next moves iterator to the next drive, false is returned when drives are over or request failed
+func (i *DriveIterator) Next() bool {
+	item, ok := i.it.next()
+	if ok {
+		i.drive = item.(*Drive)
+	}
+
+	return ok
+}
+
+// Drive returns current drive
+func (i *DriveIterator) Drive() *Drive {
+	return i.drive
+}
+
+// Err returns error which stopped iteration
+func (i *DriveIterator) Err() error {
+	return i.it.err
+}
+
+// Token returns position which resumes iteration after current drive
+func (i *DriveIterator) Token() *IteratorToken {
+	return i.it.token()
+}
+
+// Close stops requesting of pages
+func (i *DriveIterator) Close() {
+	i.it.close()
+}
+
+// Collect returns the remaining drives, but not more than max. Zero max collects every drive
+func (i *DriveIterator) Collect(max int) ([]*Drive, error) {
+	drives := make([]*Drive, 0)
+	err := i.it.collect(max, func(item interface{}) {
+		drives = append(drives, item.(*Drive))
+	})
+
+	return drives, err
+}
+
+// TransactionIterator walks transactions of every page of TransactionService.GetTransactionsByGroup
+type TransactionIterator struct {
+	it *pageIterator
+	tx Transaction
+}
+
+// Next moves iterator to the next transaction, false is returned when transactions are over or request failed
+func (i *TransactionIterator) Next() bool {
+	item, ok := i.it.next()
+	if ok {
+		i.tx = item.(Transaction)
+	}
+
+	return ok
+}
+
+// Transaction returns current transaction
+func (i *TransactionIterator) Transaction() Transaction {
+	return i.tx
+}
+
+// Err returns error which stopped iteration
+func (i *TransactionIterator) Err() error {
+	return i.it.err
+}
+
+// Token returns position which resumes iteration after current transaction
+func (i *TransactionIterator) Token() *IteratorToken {
+	return i.it.token()
+}
+
+// Close stops requesting of pages
+func (i *TransactionIterator) Close() {
+	i.it.close()
+}
+
+// Collect returns the remaining transactions, but not more than max. Zero max collects every transaction
+func (i *TransactionIterator) Collect(max int) ([]Transaction, error) {
+	txs := make([]Transaction, 0)
+	err := i.it.collect(max, func(item interface{}) {
+		txs = append(txs, item.(Transaction))
+	})
+
+	return txs, err
+}
+
+// MetadataIterator walks metadata of every page of MetadataV2Service.GetMetadataV2Infos
+type MetadataIterator struct {
+	it       *pageIterator
+	metadata *MetadataV2TupleInfo
+}
+
+// Next moves iterator to the next metadata, false is returned when metadata are over or request failed
+func (i *MetadataIterator) Next() bool {
+	item, ok := i.it.next()
+	if ok {
+		i.metadata = item.(*MetadataV2TupleInfo)
+	}
+
+	return ok
+}
+
+// Metadata returns current metadata
+func (i *MetadataIterator) Metadata() *MetadataV2TupleInfo {
+	return i.metadata
+}
+
+// Err returns error which stopped iteration
+func (i *MetadataIterator) Err() error {
+	return i.it.err
+}
+
+// Token returns position which resumes iteration after current metadata
+func (i *MetadataIterator) Token() *IteratorToken {
+	return i.it.token()
+}
+
+// Close stops requesting of pages
+func (i *MetadataIterator) Close() {
+	i.it.close()
+}
+
+// Collect returns the remaining metadata, but not more than max. Zero max collects every metadata
+func (i *MetadataIterator) Collect(max int) ([]*MetadataV2TupleInfo, error) {
+	metadatas := make([]*MetadataV2TupleInfo, 0)
+	err := i.it.collect(max, func(item interface{}) {
+		metadatas = append(metadatas, item.(*MetadataV2TupleInfo))
+	})
+
+	return metadatas, err
+}
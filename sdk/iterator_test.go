@@ -0,0 +1,155 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testPageFetcher returns pages of pageSize numbers from 0 to total
+func testPageFetcher(total, pageSize int, requests *int32) pageFetcher {
+	return func(ctx context.Context, pageNumber uint64) ([]interface{}, Pagination, error) {
+		atomic.AddInt32(requests, 1)
+
+		items := make([]interface{}, 0)
+		for i := int(pageNumber-1) * pageSize; i < int(pageNumber)*pageSize && i < total; i++ {
+			items = append(items, i)
+		}
+
+		return items, Pagination{
+			TotalEntries: uint64(total),
+			PageNumber:   pageNumber,
+			PageSize:     uint64(pageSize),
+			TotalPages:   uint64((total + pageSize - 1) / pageSize),
+		}, nil
+	}
+}
+
+func collectTestItems(it *pageIterator, max int) ([]int, error) {
+	items := make([]int, 0)
+	err := it.collect(max, func(item interface{}) {
+		items = append(items, item.(int))
+	})
+
+	return items, err
+}
+
+func TestPageIterator(t *testing.T) {
+	for _, prefetch := range []int{0, 1, 3} {
+		var requests int32
+		it := newPageIterator(ctx, testPageFetcher(7, 3, &requests), &IteratorOptions{Prefetch: prefetch})
+
+		// pages are not requested before iteration
+		assert.Equal(t, int32(0), atomic.LoadInt32(&requests))
+		assert.Equal(t, &IteratorToken{PageNumber: 1}, it.token())
+
+		items, err := collectTestItems(it, 4)
+		assert.Nil(t, err)
+		assert.Equal(t, []int{0, 1, 2, 3}, items)
+		assert.Equal(t, &IteratorToken{PageNumber: 2, Index: 1}, it.token())
+
+		items, err = collectTestItems(it, 0)
+		assert.Nil(t, err)
+		assert.Equal(t, []int{4, 5, 6}, items)
+		assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+
+		_, ok := it.next()
+		assert.False(t, ok)
+	}
+}
+
+func TestPageIterator_Token(t *testing.T) {
+	var requests int32
+	it := newPageIterator(ctx, testPageFetcher(7, 3, &requests), nil)
+
+	items, err := collectTestItems(it, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{0, 1, 2}, items)
+	it.close()
+
+	token, err := ParseIteratorToken(it.token().String())
+	assert.Nil(t, err)
+	assert.Equal(t, &IteratorToken{PageNumber: 2}, token)
+
+	it = newPageIterator(ctx, testPageFetcher(7, 3, &requests), &IteratorOptions{Token: &IteratorToken{PageNumber: 2, Index: 2}})
+	items, err = collectTestItems(it, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{5, 6}, items)
+
+	for _, s := range []string{"", "1", "0.0", "a.1", "1.-1", "1.1.1"} {
+		_, err = ParseIteratorToken(s)
+		assert.Equal(t, ErrInvalidIteratorToken, err, s)
+	}
+}
+
+func TestPageIterator_Error(t *testing.T) {
+	fetchErr := errors.New("fetch failed")
+	fetch := func(ctx context.Context, pageNumber uint64) ([]interface{}, Pagination, error) {
+		if pageNumber == 2 {
+			return nil, Pagination{}, fetchErr
+		}
+
+		return []interface{}{int(pageNumber)}, Pagination{TotalPages: 3}, nil
+	}
+
+	it := newPageIterator(ctx, fetch, nil)
+	items, err := collectTestItems(it, 0)
+	assert.Equal(t, fetchErr, err)
+	assert.Equal(t, []int{1}, items)
+	// iteration is resumed from failed page
+	assert.Equal(t, &IteratorToken{PageNumber: 2}, it.token())
+}
+
+func TestPageIterator_Cancel(t *testing.T) {
+	cancelCtx, cancel := context.WithCancel(ctx)
+
+	var requests int32
+	it := newPageIterator(cancelCtx, testPageFetcher(7, 3, &requests), nil)
+
+	_, ok := it.next()
+	assert.True(t, ok)
+
+	cancel()
+	items, err := collectTestItems(it, 0)
+	assert.Equal(t, context.Canceled, err)
+	assert.Empty(t, items)
+}
+
+func TestStorageService_IterateDrives(t *testing.T) {
+	mock := newSdkMock(0)
+	defer mock.Close()
+
+	mock.AddHandler(drivesRoute, func(resp http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "1", req.URL.Query().Get("pageSize"))
+
+		page := 1
+		fmt.Sscanf(req.URL.Query().Get("pageNumber"), "%d", &page)
+
+		fmt.Fprintf(resp, `{"data": [%s], "pagination": {"totalEntries": 2, "pageNumber": %d, "pageSize": 1, "totalPages": 2}}`, testDriveInfoJson, page)
+	})
+
+	opts := &DrivesPageOptions{PaginationOrderingOptions: PaginationOrderingOptions{PageSize: 1}}
+	drives, err := mock.getPublicTestClientUnsafe().Storage.IterateDrives(ctx, opts, nil).Collect(0)
+	assert.Nil(t, err)
+	assert.Len(t, drives, 2)
+	assert.Equal(t, testDriveAccount.PublicKey, drives[1].DriveAccount.PublicKey)
+}
+
+func TestMetadataV2Service_IterateMetadataV2Infos(t *testing.T) {
+	mock := newSdkMock(0)
+	defer mock.Close()
+
+	mock.AddHandler(metadataEntriesRoute, func(resp http.ResponseWriter, req *http.Request) {
+		fmt.Fprint(resp, `{"data": [], "pagination": {"totalEntries": 0, "pageNumber": 1, "pageSize": 20, "totalPages": 0}}`)
+	})
+
+	it := mock.getPublicTestClientUnsafe().MetadataV2.IterateMetadataV2Infos(ctx, nil, &IteratorOptions{Prefetch: 0})
+	assert.False(t, it.Next())
+	assert.Nil(t, it.Err())
+	assert.Equal(t, &IteratorToken{PageNumber: 2}, it.Token())
+}
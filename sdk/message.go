@@ -49,7 +49,17 @@ func NewPlainMessage(payload string) *PlainMessage {
 	return &PlainMessage{[]byte(payload)}
 }
 
+// NewPlainMessageFromEncodedData decrypts SecureMessageV2 envelope or legacy secure message payload
 func NewPlainMessageFromEncodedData(encodedData []byte, recipient *xpxcrypto.PrivateKey, sender *xpxcrypto.PublicKey) (*PlainMessage, error) {
+	if e, err := parseSecureMessageEnvelope(encodedData); err == nil {
+		plainText, err := decryptSecureMessageV2(e, recipient, sender)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewPlainMessage(string(plainText)), nil
+	}
+
 	rkp, err := xpxcrypto.NewKeyPair(recipient, nil, nil)
 	if err != nil {
 		return nil, err
@@ -78,6 +78,32 @@ func (ref *MetadataV2Service) GetMetadataV2Infos(ctx context.Context, mOpts *Met
 	return dtos.toStruct(ref.client.config.NetworkType)
 }
 
+// IterateMetadataV2Infos returns iterator over metadata of every page matching options,
+// call Close when iteration is stopped early
+func (ref *MetadataV2Service) IterateMetadataV2Infos(ctx context.Context, mOpts *MetadataV2PageOptions, itOpts *IteratorOptions) *MetadataIterator {
+	opts := MetadataV2PageOptions{}
+	if mOpts != nil {
+		opts = *mOpts
+	}
+
+	return &MetadataIterator{it: newPageIterator(ctx, func(ctx context.Context, pageNumber uint64) ([]interface{}, Pagination, error) {
+		pageOpts := opts
+		pageOpts.PageNumber = pageNumber
+
+		metadataPage, err := ref.GetMetadataV2Infos(ctx, &pageOpts)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+
+		items := make([]interface{}, len(metadataPage.Metadatas))
+		for i, m := range metadataPage.Metadatas {
+			items[i] = m
+		}
+
+		return items, metadataPage.Pagination, nil
+	}, itOpts)}
+}
+
 func CalculateUniqueAccountMetadataId(sourceAddress *Address, targetAccount *PublicAccount, key ScopedMetadataKey) (*Hash, error) {
 	return calculate(sourceAddress, targetAccount, key, 0, 0)
 }
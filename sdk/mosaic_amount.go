@@ -0,0 +1,105 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"context"
+	"strings"
+)
+
+// GetMosaicAmounts returns amounts of mosaics with divisibility from MosaicProperties and display names from GetMosaicsNames.
+// Asset ids of mosaics are kept, so namespace ids are resolved only to read properties and names
+func (ref *ResolverService) GetMosaicAmounts(ctx context.Context, mosaics ...*Mosaic) ([]*MosaicAmount, error) {
+	if len(mosaics) == 0 {
+		return nil, ErrEmptyAssetIds
+	}
+
+	resolved := make([]*MosaicId, len(mosaics))
+	ids := make([]*MosaicId, 0, len(mosaics))
+	seen := make(map[uint64]bool, len(mosaics))
+
+	for i, m := range mosaics {
+		if m == nil {
+			return nil, ErrNilMosaic
+		}
+
+		mosaicId, err := ref.ResolveMosaicId(ctx, m.AssetId)
+		if err != nil {
+			return nil, err
+		}
+
+		resolved[i] = mosaicId
+		if !seen[mosaicId.Id()] {
+			seen[mosaicId.Id()] = true
+			ids = append(ids, mosaicId)
+		}
+	}
+
+	infos, err := ref.MosaicService.GetMosaicInfos(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	divisibility := make(map[uint64]uint8, len(infos))
+	for _, info := range infos {
+		divisibility[info.MosaicId.Id()] = info.Properties.Divisibility
+	}
+
+	mosaicNames, err := ref.MosaicService.GetMosaicsNames(ctx, ids...)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[uint64]string, len(mosaicNames))
+	for _, n := range mosaicNames {
+		if len(n.Names) > 0 {
+			names[n.MosaicId.Id()] = n.Names[0]
+		}
+	}
+
+	amounts := make([]*MosaicAmount, len(mosaics))
+	for i, m := range mosaics {
+		d, ok := divisibility[resolved[i].Id()]
+		if !ok {
+			return nil, ErrResourceNotFound
+		}
+
+		amounts[i], err = NewMosaicAmount(m.AssetId, m.Amount, d)
+		if err != nil {
+			return nil, err
+		}
+
+		amounts[i].Name = names[resolved[i].Id()]
+	}
+
+	return amounts, nil
+}
+
+// ParseMosaicAmount parses decimal amount followed by name of namespace linked to mosaic like '12.345678 prx.xpx'
+// or '12.345678 xpx', divisibility is taken from properties of linked mosaic
+func (ref *ResolverService) ParseMosaicAmount(ctx context.Context, s string) (*MosaicAmount, error) {
+	fields := strings.Fields(s)
+	if len(fields) != 2 {
+		return nil, ErrInvalidMosaicAmount
+	}
+
+	// 'xpx' is a short name of XPX namespace, not a root namespace
+	namespaceId := XpxNamespaceId
+	if fields[1] != "xpx" {
+		id, err := NewNamespaceIdFromName(fields[1])
+		if err != nil {
+			return nil, err
+		}
+
+		namespaceId = id
+	}
+
+	info, err := ref.GetMosaicInfoByAssetId(ctx, namespaceId)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseMosaicAmount(s, namespaceId, info.Properties.Divisibility)
+}
@@ -0,0 +1,285 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	// XpxDivisibility is a divisibility of XPX mosaic
+	XpxDivisibility uint8 = 6
+	// MaxDivisibility is the greatest divisibility which unit of mosaic fits Amount with
+	MaxDivisibility uint8 = 18
+)
+
+// MosaicAmount is an amount of mosaic in the smallest units with divisibility of mosaic,
+// so it can be parsed from and formatted to decimal string like '12.345678 xpx'
+type MosaicAmount struct {
+	AssetId      AssetId
+	Amount       Amount
+	Divisibility uint8
+	// Name is a display name of mosaic, it is omitted in formatted amount when it is empty
+	Name string
+}
+
+// NewMosaicAmount returns amount of asset in the smallest units
+func NewMosaicAmount(assetId AssetId, amount Amount, divisibility uint8) (*MosaicAmount, error) {
+	if assetId == nil {
+		return nil, ErrNilAssetId
+	}
+
+	if divisibility > MaxDivisibility {
+		return nil, ErrInvalidDivisibility
+	}
+
+	if amount < 0 {
+		return nil, ErrInvalidMosaicAmount
+	}
+
+	return &MosaicAmount{
+		AssetId:      assetId,
+		Amount:       amount,
+		Divisibility: divisibility,
+	}, nil
+}
+
+// ParseMosaicAmount parses decimal amount of asset with optional name after space like '12.345678 prx.xpx',
+// amount can't have more fraction digits than divisibility. Name should be a name of namespace of asset,
+// hex of mosaic id of asset or 'xpx' for XPX namespace, otherwise ErrMosaicNameMismatch is returned
+func ParseMosaicAmount(s string, assetId AssetId, divisibility uint8) (*MosaicAmount, error) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 || len(fields) > 2 {
+		return nil, ErrInvalidMosaicAmount
+	}
+
+	amount, err := parseDecimalAmount(fields[0], divisibility)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := NewMosaicAmount(assetId, amount, divisibility)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(fields) == 2 {
+		if !isMosaicName(fields[1], assetId) {
+			return nil, ErrMosaicNameMismatch
+		}
+
+		m.Name = fields[1]
+	}
+
+	return m, nil
+}
+
+// ParseXpx parses decimal amount of XPX like '12.345678', '12.345678 xpx' or '12.345678 prx.xpx'
+func ParseXpx(s string) (*MosaicAmount, error) {
+	m, err := ParseMosaicAmount(s, XpxNamespaceId, XpxDivisibility)
+	if err != nil {
+		return nil, err
+	}
+
+	if m.Name == "" {
+		m.Name = "xpx"
+	}
+
+	return m, nil
+}
+
+// Mosaic returns mosaic with amount in the smallest units, it can be passed to transfer transaction
+func (m *MosaicAmount) Mosaic() *Mosaic {
+	return newMosaicPanic(m.AssetId, m.Amount)
+}
+
+// Add returns sum of amounts of the same mosaic
+func (m *MosaicAmount) Add(other *MosaicAmount) (*MosaicAmount, error) {
+	if err := m.compatible(other); err != nil {
+		return nil, err
+	}
+
+	amount, err := addAmount(m.Amount, other.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.withAmount(amount), nil
+}
+
+// Sub returns difference of amounts of the same mosaic, amount can't become negative
+func (m *MosaicAmount) Sub(other *MosaicAmount) (*MosaicAmount, error) {
+	if err := m.compatible(other); err != nil {
+		return nil, err
+	}
+
+	if other.Amount > m.Amount {
+		return nil, ErrMosaicAmountOverflow
+	}
+
+	return m.withAmount(m.Amount - other.Amount), nil
+}
+
+// Mul returns amount multiplied by non-negative factor
+func (m *MosaicAmount) Mul(factor int64) (*MosaicAmount, error) {
+	if factor < 0 {
+		return nil, ErrInvalidMosaicAmount
+	}
+
+	amount, err := mulAmount(m.Amount, factor)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.withAmount(amount), nil
+}
+
+// Decimal returns amount as decimal string with divisibility fraction digits, negative amount is prefixed with minus
+func (m *MosaicAmount) Decimal() string {
+	if m.Divisibility == 0 {
+		return strconv.FormatInt(int64(m.Amount), 10)
+	}
+
+	sign := ""
+	// absolute value is taken in uint64, so it doesn't overflow for the smallest Amount
+	abs := uint64(m.Amount)
+	if m.Amount < 0 {
+		sign, abs = "-", -abs
+	}
+
+	unit := uint64(pow10(m.Divisibility))
+	frac := strconv.FormatUint(abs%unit, 10)
+
+	return sign + strconv.FormatUint(abs/unit, 10) + "." + strings.Repeat("0", int(m.Divisibility)-len(frac)) + frac
+}
+
+// String returns decimal amount followed by name of mosaic when it is known
+func (m *MosaicAmount) String() string {
+	if m.Name == "" {
+		return m.Decimal()
+	}
+
+	return m.Decimal() + " " + m.Name
+}
+
+func (m *MosaicAmount) compatible(other *MosaicAmount) error {
+	if other == nil || m.AssetId == nil || other.AssetId == nil {
+		return ErrNilMosaic
+	}
+
+	if m.AssetId.Id() != other.AssetId.Id() || m.Divisibility != other.Divisibility {
+		return ErrMosaicAmountMismatch
+	}
+
+	return nil
+}
+
+func (m *MosaicAmount) withAmount(amount Amount) *MosaicAmount {
+	c := *m
+	c.Amount = amount
+
+	return &c
+}
+
+// isMosaicName checks that name is a name of namespace of asset, hex of mosaic id of asset or 'xpx' for XPX namespace
+func isMosaicName(name string, assetId AssetId) bool {
+	switch id := assetId.(type) {
+	case *NamespaceId:
+		if name == "xpx" {
+			return id.Id() == XpxNamespaceId.Id()
+		}
+
+		namespaceId, err := NewNamespaceIdFromName(name)
+		return err == nil && namespaceId.Id() == id.Id()
+	case *MosaicId:
+		return strings.EqualFold(name, id.toHexString())
+	}
+
+	return false
+}
+
+// parseDecimalAmount returns amount in the smallest units from decimal string
+func parseDecimalAmount(s string, divisibility uint8) (Amount, error) {
+	if divisibility > MaxDivisibility {
+		return 0, ErrInvalidDivisibility
+	}
+
+	parts := strings.SplitN(s, ".", 2)
+	if !isDigits(parts[0]) || (len(parts) == 2 && !isDigits(parts[1])) {
+		return 0, ErrInvalidMosaicAmount
+	}
+
+	frac := ""
+	if len(parts) == 2 {
+		frac = parts[1]
+	}
+
+	if len(frac) > int(divisibility) {
+		return 0, ErrInvalidMosaicAmount
+	}
+
+	whole, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, ErrMosaicAmountOverflow
+	}
+
+	amount, err := mulAmount(Amount(whole), int64(pow10(divisibility)))
+	if err != nil {
+		return 0, err
+	}
+
+	if frac == "" {
+		return amount, nil
+	}
+
+	fracAmount, err := strconv.ParseInt(frac+strings.Repeat("0", int(divisibility)-len(frac)), 10, 64)
+	if err != nil {
+		return 0, ErrInvalidMosaicAmount
+	}
+
+	return addAmount(amount, Amount(fracAmount))
+}
+
+// addAmount returns sum of non-negative amounts
+func addAmount(a, b Amount) (Amount, error) {
+	if a > math.MaxInt64-b {
+		return 0, ErrMosaicAmountOverflow
+	}
+
+	return a + b, nil
+}
+
+func mulAmount(amount Amount, factor int64) (Amount, error) {
+	if factor != 0 && amount > Amount(math.MaxInt64/factor) {
+		return 0, ErrMosaicAmountOverflow
+	}
+
+	return amount * Amount(factor), nil
+}
+
+func pow10(n uint8) Amount {
+	p := Amount(1)
+	for i := uint8(0); i < n; i++ {
+		p *= 10
+	}
+
+	return p
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return true
+}
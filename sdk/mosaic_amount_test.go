@@ -0,0 +1,196 @@
+package sdk
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/proximax-storage/go-xpx-utils/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMosaicAmount(t *testing.T) {
+	for s, expected := range map[string]Amount{
+		"12.345678 xpx": 12345678,
+		"12.5":          12500000,
+		"0.000001":      1,
+		"7":             7000000,
+	} {
+		m, err := ParseMosaicAmount(s, XpxNamespaceId, XpxDivisibility)
+		assert.Nil(t, err, s)
+		assert.Equal(t, expected, m.Amount, s)
+	}
+
+	m, err := ParseMosaicAmount("12.345678 xpx", XpxNamespaceId, XpxDivisibility)
+	assert.Nil(t, err)
+	assert.Equal(t, "xpx", m.Name)
+	assert.Equal(t, "12.345678 xpx", m.String())
+
+	for _, s := range []string{"", "1.2345678", "-1", "1.", ".5", "1,5", "1 2 3", "x"} {
+		_, err := ParseMosaicAmount(s, XpxNamespaceId, XpxDivisibility)
+		assert.Equal(t, ErrInvalidMosaicAmount, err, s)
+	}
+
+	_, err = ParseMosaicAmount("9223372036854.775808", XpxNamespaceId, XpxDivisibility)
+	assert.Equal(t, ErrMosaicAmountOverflow, err)
+
+	m, err = ParseMosaicAmount("9223372036854.775807", XpxNamespaceId, XpxDivisibility)
+	assert.Nil(t, err)
+	assert.Equal(t, Amount(math.MaxInt64), m.Amount)
+
+	_, err = ParseMosaicAmount("1", XpxNamespaceId, 19)
+	assert.Equal(t, ErrInvalidDivisibility, err)
+
+	m, err = ParseMosaicAmount("15", StorageNamespaceId, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, "15", m.String())
+
+	m, err = ParseXpx("0.05")
+	assert.Nil(t, err)
+	assert.Equal(t, "0.050000 xpx", m.String())
+	assert.Equal(t, Xpx(50000), m.Mosaic())
+
+	m, err = ParseXpx("5 prx.xpx")
+	assert.Nil(t, err)
+	assert.Equal(t, "5.000000 prx.xpx", m.String())
+
+	_, err = ParseXpx("5 btc")
+	assert.Equal(t, ErrMosaicNameMismatch, err)
+
+	_, err = ParseMosaicAmount("5 xpx", StorageNamespaceId, 0)
+	assert.Equal(t, ErrMosaicNameMismatch, err)
+
+	m, err = ParseMosaicAmount("5 "+testHistoryMosaicId.toHexString(), testHistoryMosaicId, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, Amount(5), m.Amount)
+}
+
+func TestMosaicAmount_Decimal(t *testing.T) {
+	for amount, expected := range map[Amount]string{
+		1:             "0.000001",
+		-1:            "-0.000001",
+		-12345678:     "-12.345678",
+		math.MinInt64: "-9223372036854.775808",
+	} {
+		m := &MosaicAmount{AssetId: XpxNamespaceId, Amount: amount, Divisibility: XpxDivisibility, Name: "xpx"}
+		assert.Equal(t, expected, m.Decimal())
+		assert.Equal(t, expected+" xpx", m.String())
+	}
+
+	assert.Equal(t, "-15", (&MosaicAmount{AssetId: StorageNamespaceId, Amount: -15}).String())
+}
+
+func TestMosaicAmount_Arithmetic(t *testing.T) {
+	a, err := ParseXpx("1.5")
+	assert.Nil(t, err)
+	b, err := ParseXpx("0.25")
+	assert.Nil(t, err)
+
+	sum, err := a.Add(b)
+	assert.Nil(t, err)
+	assert.Equal(t, "1.750000 xpx", sum.String())
+	// operands aren't changed
+	assert.Equal(t, Amount(1500000), a.Amount)
+
+	diff, err := a.Sub(b)
+	assert.Nil(t, err)
+	assert.Equal(t, Amount(1250000), diff.Amount)
+
+	_, err = b.Sub(a)
+	assert.Equal(t, ErrMosaicAmountOverflow, err)
+
+	product, err := b.Mul(4)
+	assert.Nil(t, err)
+	assert.Equal(t, "1.000000 xpx", product.String())
+
+	max, err := NewMosaicAmount(XpxNamespaceId, math.MaxInt64, XpxDivisibility)
+	assert.Nil(t, err)
+
+	_, err = max.Add(b)
+	assert.Equal(t, ErrMosaicAmountOverflow, err)
+
+	_, err = max.Mul(2)
+	assert.Equal(t, ErrMosaicAmountOverflow, err)
+
+	storage, err := NewMosaicAmount(StorageNamespaceId, 1, XpxDivisibility)
+	assert.Nil(t, err)
+
+	_, err = a.Add(storage)
+	assert.Equal(t, ErrMosaicAmountMismatch, err)
+}
+
+func TestResolverService_GetMosaicAmounts(t *testing.T) {
+	mockServer := newSdkMock(0)
+	defer mockServer.Close()
+
+	mockServer.AddRouter(&mock.Router{
+		Path:     mosaicsRoute,
+		RespBody: "[" + testMosaicInfoJson + "]",
+	})
+	mockServer.AddRouter(&mock.Router{
+		Path:     mosaicNamesRoute,
+		RespBody: `[{"mosaicId": [298950589, 1817567325], "names": ["prx.xpx"]}]`,
+	})
+	mockServer.AddRouter(&mock.Router{
+		Path:     fmt.Sprintf(mosaicRoute, testMosaicPathID),
+		RespBody: testMosaicInfoJson,
+	})
+
+	client := mockServer.getPublicTestClientUnsafe()
+
+	amounts, err := client.Resolve.GetMosaicAmounts(ctx, newMosaicPanic(mosaicCorr.MosaicId, 12345678), newMosaicPanic(mosaicCorr.MosaicId, 1))
+	assert.Nil(t, err)
+	assert.Len(t, amounts, 2)
+	assert.Equal(t, "12.345678 prx.xpx", amounts[0].String())
+	assert.Equal(t, "0.000001 prx.xpx", amounts[1].String())
+
+	tx, err := client.NewTransferTransactionWithAmounts(NewDeadline(time.Hour), testMarketOwner.Address, amounts, NewPlainMessage(""))
+	assert.Nil(t, err)
+	assert.Equal(t, Amount(12345678), tx.Mosaics[0].Amount)
+
+	_, err = client.Resolve.GetMosaicAmounts(ctx)
+	assert.Equal(t, ErrEmptyAssetIds, err)
+}
+
+func TestResolverService_ParseMosaicAmount(t *testing.T) {
+	mockServer := newSdkMock(0)
+	defer mockServer.Close()
+
+	namespaceId, err := NewNamespaceIdFromName("token")
+	assert.Nil(t, err)
+
+	mockServer.AddRouter(&mock.Router{
+		Path: fmt.Sprintf(namespaceRoute, namespaceId.toHexString()),
+		RespBody: strings.Replace(testRenewalNamespaceJson(testMarketOwner, namespaceId, 10000),
+			`"alias": {"type": 0}`, `"alias": {"type": 1, "mosaicId": [298950589, 1817567325]}`, 1),
+	})
+	mockServer.AddRouter(&mock.Router{
+		Path: fmt.Sprintf(namespaceRoute, XpxNamespaceId.toHexString()),
+		RespBody: strings.Replace(testRenewalNamespaceJson(testMarketOwner, XpxNamespaceId, 10000),
+			`"alias": {"type": 0}`, `"alias": {"type": 1, "mosaicId": [298950589, 1817567325]}`, 1),
+	})
+	mockServer.AddRouter(&mock.Router{
+		Path:     fmt.Sprintf(mosaicRoute, testMosaicPathID),
+		RespBody: testMosaicInfoJson,
+	})
+
+	client := mockServer.getPublicTestClientUnsafe()
+
+	// short name of XPX is resolved by prx.xpx namespace
+	m, err := client.Resolve.ParseMosaicAmount(ctx, "12.345678 xpx")
+	assert.Nil(t, err)
+	assert.Equal(t, Amount(12345678), m.Amount)
+	assert.Equal(t, XpxNamespaceId, m.AssetId)
+	assert.Equal(t, "12.345678 xpx", m.String())
+
+	m, err = client.Resolve.ParseMosaicAmount(ctx, "2.5 token")
+	assert.Nil(t, err)
+	assert.Equal(t, Amount(2500000), m.Amount)
+	assert.Equal(t, namespaceId, m.AssetId)
+	assert.Equal(t, "2.500000 token", m.String())
+
+	_, err = client.Resolve.ParseMosaicAmount(ctx, "2.5")
+	assert.Equal(t, ErrInvalidMosaicAmount, err)
+}
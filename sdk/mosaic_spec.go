@@ -0,0 +1,217 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"context"
+)
+
+// NewMosaicIssuance returns issuance which creates mosaic of spec end to end: defines mosaic, increases its supply,
+// sets levy and links namespace of spec to it. Aggregate of issuance isn't signed or announced
+func (ref *MosaicService) NewMosaicIssuance(deadline *Deadline, owner *PublicAccount, spec *MosaicSpec) (*MosaicIssuance, error) {
+	if err := spec.validate(); err != nil {
+		return nil, err
+	}
+
+	mosaicId, err := spec.MosaicId(owner)
+	if err != nil {
+		return nil, err
+	}
+
+	i := &MosaicIssuance{
+		MosaicId:     mosaicId,
+		Transactions: make([]Transaction, 0, 4),
+	}
+
+	defTx, err := ref.client.NewMosaicDefinitionTransaction(deadline, spec.Nonce, owner.PublicKey, spec.Properties())
+	if err != nil {
+		return nil, err
+	}
+
+	i.Transactions = append(i.Transactions, defTx)
+
+	if err := ref.changeSupply(deadline, i, 0, spec.Supply); err != nil {
+		return nil, err
+	}
+
+	if levy := spec.levyOf(mosaicId); levy != nil {
+		levyTx, err := ref.client.NewMosaicModifyLevyTransaction(deadline, mosaicId, levy)
+		if err != nil {
+			return nil, err
+		}
+
+		i.Transactions = append(i.Transactions, levyTx)
+	}
+
+	if spec.Name != "" {
+		namespaceId, err := NewNamespaceIdFromName(spec.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		aliasTx, err := ref.client.NewMosaicAliasTransaction(deadline, mosaicId, namespaceId, AliasLink)
+		if err != nil {
+			return nil, err
+		}
+
+		i.Transactions = append(i.Transactions, aliasTx)
+	}
+
+	return i, ref.aggregateIssuance(deadline, owner, i)
+}
+
+// DiffMosaicIssuance returns issuance which brings existing mosaic of owner in line with spec: it changes supply,
+// sets or removes levy and relinks namespace of spec. Mosaic is created when it doesn't exist yet.
+// Properties of existing mosaic aren't changed, ErrMosaicSpecConflict is returned when they differ from spec
+func (ref *MosaicService) DiffMosaicIssuance(ctx context.Context, deadline *Deadline, owner *PublicAccount, spec *MosaicSpec) (*MosaicIssuance, error) {
+	if err := spec.validate(); err != nil {
+		return nil, err
+	}
+
+	mosaicId, err := spec.MosaicId(owner)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := ref.GetMosaicInfo(ctx, mosaicId)
+	if isNotFoundError(err) {
+		return ref.NewMosaicIssuance(deadline, owner, spec)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !sameProperties(info.Properties, spec.Properties()) || (info.Supply != spec.Supply && !spec.SupplyMutable) {
+		return nil, ErrMosaicSpecConflict
+	}
+
+	i := &MosaicIssuance{
+		MosaicId:     mosaicId,
+		Existing:     info,
+		Transactions: make([]Transaction, 0, 3),
+	}
+
+	if err := ref.changeSupply(deadline, i, info.Supply, spec.Supply); err != nil {
+		return nil, err
+	}
+
+	if err := ref.diffLevy(ctx, deadline, i, spec.levyOf(mosaicId)); err != nil {
+		return nil, err
+	}
+
+	if err := ref.diffAlias(ctx, deadline, i, spec.Name); err != nil {
+		return nil, err
+	}
+
+	return i, ref.aggregateIssuance(deadline, owner, i)
+}
+
+func (ref *MosaicService) changeSupply(deadline *Deadline, i *MosaicIssuance, from, to Amount) error {
+	if from == to {
+		return nil
+	}
+
+	supplyType, delta := Increase, to-from
+	if delta < 0 {
+		supplyType, delta = Decrease, -delta
+	}
+
+	tx, err := ref.client.NewMosaicSupplyChangeTransaction(deadline, i.MosaicId, supplyType, delta)
+	if err != nil {
+		return err
+	}
+
+	i.Transactions = append(i.Transactions, tx)
+
+	return nil
+}
+
+func (ref *MosaicService) diffLevy(ctx context.Context, deadline *Deadline, i *MosaicIssuance, levy *MosaicLevy) error {
+	current, err := ref.GetMosaicLevy(ctx, i.MosaicId)
+	if err != nil && !isNotFoundError(err) {
+		return err
+	}
+
+	if sameLevy(current, levy) {
+		return nil
+	}
+
+	var tx Transaction
+	if levy == nil {
+		tx, err = ref.client.NewMosaicRemoveLevyTransaction(deadline, i.MosaicId)
+	} else {
+		tx, err = ref.client.NewMosaicModifyLevyTransaction(deadline, i.MosaicId, levy)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	i.Transactions = append(i.Transactions, tx)
+
+	return nil
+}
+
+// diffAlias links namespace to mosaic, previous alias of namespace is unlinked before
+func (ref *MosaicService) diffAlias(ctx context.Context, deadline *Deadline, i *MosaicIssuance, name string) error {
+	if name == "" {
+		return nil
+	}
+
+	namespaceId, err := NewNamespaceIdFromName(name)
+	if err != nil {
+		return err
+	}
+
+	info, err := ref.client.Namespace.GetNamespaceInfo(ctx, namespaceId)
+	if err != nil {
+		return err
+	}
+
+	alias := &NamespaceAlias{mosaicId: i.MosaicId, Type: MosaicAliasType}
+	if info.Alias != nil && sameAlias(info.Alias, alias) {
+		return nil
+	}
+
+	if info.Alias != nil {
+		tx, err := ref.client.Namespace.aliasTransaction(deadline, info.Alias, namespaceId, AliasUnlink)
+		if err != nil {
+			return err
+		}
+
+		if tx != nil {
+			i.Transactions = append(i.Transactions, tx)
+		}
+	}
+
+	tx, err := ref.client.Namespace.aliasTransaction(deadline, alias, namespaceId, AliasLink)
+	if err != nil {
+		return err
+	}
+
+	i.Transactions = append(i.Transactions, tx)
+
+	return nil
+}
+
+func (ref *MosaicService) aggregateIssuance(deadline *Deadline, owner *PublicAccount, i *MosaicIssuance) error {
+	if i.IsEmpty() {
+		return nil
+	}
+
+	for _, tx := range i.Transactions {
+		tx.GetAbstractTransaction().ToAggregate(owner)
+	}
+
+	aggTx, err := ref.client.NewCompleteAggregateTransaction(deadline, i.Transactions)
+	if err != nil {
+		return err
+	}
+
+	i.Aggregate = aggTx
+
+	return nil
+}
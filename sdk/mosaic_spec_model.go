@@ -0,0 +1,110 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"github.com/proximax-storage/go-xpx-utils/str"
+)
+
+// MosaicSpec declares mosaic of owner, id of mosaic is derived from Nonce and public key of owner
+type MosaicSpec struct {
+	Nonce uint32
+	// Name of namespace which is linked to mosaic when it isn't empty, namespace should be registered by owner
+	Name         string
+	Divisibility uint8
+	// Supply is a total supply in the smallest units of mosaic
+	Supply        Amount
+	SupplyMutable bool
+	Transferable  bool
+	// Duration of mosaic in blocks, zero duration means that mosaic never expires
+	Duration Duration
+	// Levy is paid by senders of mosaic, mosaic has no levy when it is nil or has LevyNone type.
+	// Levy is paid in the mosaic itself when MosaicId of levy is nil
+	Levy *MosaicLevy
+}
+
+func (s *MosaicSpec) validate() error {
+	if s == nil || s.Supply < 0 || s.Duration < 0 {
+		return ErrInvalidMosaicSpec
+	}
+
+	if s.Levy != nil && s.Levy.Type != LevyNone && (s.Levy.Recipient == nil || s.Levy.Fee < 0) {
+		return ErrInvalidMosaicSpec
+	}
+
+	return nil
+}
+
+// MosaicId returns id of mosaic created by owner
+func (s *MosaicSpec) MosaicId(owner *PublicAccount) (*MosaicId, error) {
+	if owner == nil {
+		return nil, ErrNilAccount
+	}
+
+	return NewMosaicIdFromNonceAndOwner(s.Nonce, owner.PublicKey)
+}
+
+// Properties returns properties of mosaic definition
+func (s *MosaicSpec) Properties() *MosaicProperties {
+	return NewMosaicProperties(s.SupplyMutable, s.Transferable, s.Divisibility, s.Duration)
+}
+
+// levyOf returns copy of levy with filled mosaic id, it is nil when mosaic has no levy
+func (s *MosaicSpec) levyOf(mosaicId *MosaicId) *MosaicLevy {
+	if s.Levy == nil || s.Levy.Type == LevyNone {
+		return nil
+	}
+
+	levy := *s.Levy
+	if levy.MosaicId == nil {
+		levy.MosaicId = mosaicId
+	}
+
+	return &levy
+}
+
+// MosaicIssuance contains transactions which bring mosaic in line with spec
+type MosaicIssuance struct {
+	MosaicId *MosaicId
+	// Existing is info of mosaic before issuance, it is nil when mosaic is created
+	Existing     *MosaicInfo
+	Transactions []Transaction
+	// Aggregate contains every transaction of issuance signed by owner, it is nil when mosaic already matches spec
+	Aggregate *AggregateTransaction
+}
+
+// IsEmpty returns true when mosaic already matches spec
+func (i *MosaicIssuance) IsEmpty() bool {
+	return len(i.Transactions) == 0
+}
+
+func (i *MosaicIssuance) String() string {
+	return str.StructToString(
+		"MosaicIssuance",
+		str.NewField("MosaicId", str.StringPattern, i.MosaicId),
+		str.NewField("Existing", str.StringPattern, i.Existing),
+		str.NewField("Transactions", str.StringPattern, i.Transactions),
+	)
+}
+
+// sameProperties returns true when properties define the same mosaic
+func sameProperties(a, b *MosaicProperties) bool {
+	return a.SupplyMutable == b.SupplyMutable &&
+		a.Transferable == b.Transferable &&
+		a.Divisibility == b.Divisibility &&
+		a.Duration() == b.Duration()
+}
+
+// sameLevy returns true when levies charge the same fee to the same recipient, nil levy is equal to levy of LevyNone type
+func sameLevy(a, b *MosaicLevy) bool {
+	if a == nil || a.Type == LevyNone || b == nil || b.Type == LevyNone {
+		return (a == nil || a.Type == LevyNone) && (b == nil || b.Type == LevyNone)
+	}
+
+	return a.Type == b.Type &&
+		a.Fee == b.Fee &&
+		a.Recipient != nil && b.Recipient != nil && a.Recipient.Address == b.Recipient.Address &&
+		a.MosaicId != nil && b.MosaicId != nil && a.MosaicId.Id() == b.MosaicId.Id()
+}
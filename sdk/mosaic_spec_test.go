@@ -0,0 +1,174 @@
+package sdk
+
+import (
+	"encoding/base32"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/proximax-storage/go-xpx-utils/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func testSpecMosaicJson(owner *PublicAccount, mosaicId *MosaicId, supply int, flags int) string {
+	return fmt.Sprintf(`{
+		"mosaic": {
+			"mosaicId": [%d, %d],
+			"supply": [%d, 0],
+			"height": [1, 0],
+			"owner": "%s",
+			"revision": 1,
+			"properties": [{"id": 0, "value": [%d, 0]}, {"id": 1, "value": [2, 0]}, {"id": 2, "value": [0, 0]}]
+		}
+	}`, uint32(mosaicId.Id()), uint32(mosaicId.Id()>>32), supply, owner.PublicKey, flags)
+}
+
+func newTestMosaicSpec() *MosaicSpec {
+	return &MosaicSpec{
+		Nonce:         7,
+		Name:          "token",
+		Divisibility:  2,
+		Supply:        1000,
+		SupplyMutable: true,
+		Transferable:  true,
+		Levy: &MosaicLevy{
+			Type:      LevyAbsoluteFee,
+			Recipient: testMarketOwner.Address,
+			Fee:       10,
+		},
+	}
+}
+
+func TestMosaicService_NewMosaicIssuance(t *testing.T) {
+	mockServer := newSdkMock(0)
+	defer mockServer.Close()
+
+	client := mockServer.getPublicTestClientUnsafe()
+	client.config.GenerationHash = &Hash{}
+
+	owner, err := NewAccount(PublicTest, &Hash{})
+	assert.Nil(t, err)
+
+	spec := newTestMosaicSpec()
+	i, err := client.Mosaic.NewMosaicIssuance(NewDeadline(time.Hour), owner.PublicAccount, spec)
+	assert.Nil(t, err)
+	assert.Nil(t, i.Existing)
+	assert.Len(t, i.Transactions, 4)
+	assert.Len(t, i.Aggregate.InnerTransactions, 4)
+
+	def := i.Transactions[0].(*MosaicDefinitionTransaction)
+	assert.Equal(t, i.MosaicId, def.MosaicId)
+	assert.Equal(t, Amount(1000), i.Transactions[1].(*MosaicSupplyChangeTransaction).Delta)
+
+	// levy is paid in the issued mosaic, spec isn't changed
+	levy := i.Transactions[2].(*MosaicModifyLevyTransaction).MosaicLevy
+	assert.Equal(t, i.MosaicId, levy.MosaicId)
+	assert.Nil(t, spec.Levy.MosaicId)
+
+	namespaceId, err := NewNamespaceIdFromName("token")
+	assert.Nil(t, err)
+	assert.Equal(t, namespaceId, i.Transactions[3].(*MosaicAliasTransaction).NamespaceId)
+
+	_, err = client.Mosaic.NewMosaicIssuance(NewDeadline(time.Hour), owner.PublicAccount, &MosaicSpec{Supply: -1})
+	assert.Equal(t, ErrInvalidMosaicSpec, err)
+
+	_, err = client.Mosaic.NewMosaicIssuance(NewDeadline(time.Hour), owner.PublicAccount, &MosaicSpec{Levy: &MosaicLevy{Type: LevyPercentileFee}})
+	assert.Equal(t, ErrInvalidMosaicSpec, err)
+}
+
+func TestMosaicService_DiffMosaicIssuance(t *testing.T) {
+	mockServer := newSdkMock(0)
+	defer mockServer.Close()
+
+	owner, err := NewAccount(PublicTest, &Hash{})
+	assert.Nil(t, err)
+
+	spec := newTestMosaicSpec()
+	mosaicId, err := spec.MosaicId(owner.PublicAccount)
+	assert.Nil(t, err)
+
+	namespaceId, err := NewNamespaceIdFromName("token")
+	assert.Nil(t, err)
+
+	mockServer.AddRouter(&mock.Router{
+		Path:     fmt.Sprintf(mosaicRoute, mosaicId.toHexString()),
+		RespBody: testSpecMosaicJson(owner.PublicAccount, mosaicId, 1500, Supply_Mutable|Transferable),
+	})
+	// namespace is linked to another mosaic
+	mockServer.AddRouter(&mock.Router{
+		Path: fmt.Sprintf(namespaceRoute, namespaceId.toHexString()),
+		RespBody: strings.Replace(testRenewalNamespaceJson(owner.PublicAccount, namespaceId, 10000),
+			`"alias": {"type": 0}`, `"alias": {"type": 1, "mosaicId": [1382215848, 1583663204]}`, 1),
+	})
+
+	client := mockServer.getPublicTestClientUnsafe()
+	client.config.GenerationHash = &Hash{}
+
+	i, err := client.Mosaic.DiffMosaicIssuance(ctx, NewDeadline(time.Hour), owner.PublicAccount, spec)
+	assert.Nil(t, err)
+	assert.Equal(t, Amount(1500), i.Existing.Supply)
+	assert.Len(t, i.Transactions, 4)
+
+	supply := i.Transactions[0].(*MosaicSupplyChangeTransaction)
+	assert.Equal(t, Decrease, supply.MosaicSupplyType)
+	assert.Equal(t, Amount(500), supply.Delta)
+	assert.IsType(t, &MosaicModifyLevyTransaction{}, i.Transactions[1])
+	assert.Equal(t, AliasUnlink, i.Transactions[2].(*MosaicAliasTransaction).ActionType)
+	assert.Equal(t, AliasLink, i.Transactions[3].(*MosaicAliasTransaction).ActionType)
+
+	_, err = owner.Sign(i.Aggregate)
+	assert.Nil(t, err)
+
+	// properties of existing mosaic can't be changed
+	spec.Transferable = false
+	_, err = client.Mosaic.DiffMosaicIssuance(ctx, NewDeadline(time.Hour), owner.PublicAccount, spec)
+	assert.Equal(t, ErrMosaicSpecConflict, err)
+
+	// missing mosaic is created
+	spec.Nonce = 8
+	i, err = client.Mosaic.DiffMosaicIssuance(ctx, NewDeadline(time.Hour), owner.PublicAccount, spec)
+	assert.Nil(t, err)
+	assert.Nil(t, i.Existing)
+	assert.IsType(t, &MosaicDefinitionTransaction{}, i.Transactions[0])
+}
+
+func TestMosaicService_DiffMosaicIssuance_Matching(t *testing.T) {
+	mockServer := newSdkMock(0)
+	defer mockServer.Close()
+
+	owner, err := NewAccount(PublicTest, &Hash{})
+	assert.Nil(t, err)
+
+	spec := newTestMosaicSpec()
+	spec.Name = ""
+	mosaicId, err := spec.MosaicId(owner.PublicAccount)
+	assert.Nil(t, err)
+
+	raw, _ := base32.StdEncoding.DecodeString(testMarketOwner.Address.Address)
+	mockServer.AddRouter(&mock.Router{
+		Path:     fmt.Sprintf(mosaicRoute, mosaicId.toHexString()),
+		RespBody: testSpecMosaicJson(owner.PublicAccount, mosaicId, 1000, Supply_Mutable|Transferable),
+	})
+	mockServer.AddHandler(fmt.Sprintf(mosaicLevyRoute, mosaicId.toHexString()), func(resp http.ResponseWriter, req *http.Request) {
+		fmt.Fprintf(resp, `{"type": 1, "recipient": "%s", "mosaicId": [%d, %d], "fee": [10, 0]}`,
+			strings.ToUpper(hex.EncodeToString(raw)), uint32(mosaicId.Id()), uint32(mosaicId.Id()>>32))
+	})
+
+	client := mockServer.getPublicTestClientUnsafe()
+	client.config.GenerationHash = &Hash{}
+
+	i, err := client.Mosaic.DiffMosaicIssuance(ctx, NewDeadline(time.Hour), owner.PublicAccount, spec)
+	assert.Nil(t, err)
+	assert.True(t, i.IsEmpty())
+	assert.Nil(t, i.Aggregate)
+
+	// levy is removed when spec has no levy
+	spec.Levy = nil
+	i, err = client.Mosaic.DiffMosaicIssuance(ctx, NewDeadline(time.Hour), owner.PublicAccount, spec)
+	assert.Nil(t, err)
+	assert.Len(t, i.Transactions, 1)
+	assert.IsType(t, &MosaicRemoveLevyTransaction{}, i.Transactions[0])
+}
@@ -43,6 +43,8 @@ func (ref *NamespaceService) GetNamespaceInfo(ctx context.Context, nsId *Namespa
 		return nil, err
 	}
 
+	ref.client.namespaceCache.Put(nsInfo)
+
 	return nsInfo, nil
 }
 
@@ -154,13 +156,13 @@ func (ref *NamespaceService) GetLinkedMosaicId(ctx context.Context, namespaceId
 		return nil, ErrNilAddress
 	}
 
-	info, err := ref.GetNamespaceInfo(ctx, namespaceId)
+	alias, err := ref.getAlias(ctx, namespaceId)
 
 	if err != nil {
 		return nil, err
 	}
 
-	return info.Alias.MosaicId(), nil
+	return alias.MosaicId(), nil
 }
 
 // GetLinkedAddress
@@ -170,13 +172,31 @@ func (ref *NamespaceService) GetLinkedAddress(ctx context.Context, namespaceId *
 		return nil, ErrNilAddress
 	}
 
-	info, err := ref.GetNamespaceInfo(ctx, namespaceId)
+	alias, err := ref.getAlias(ctx, namespaceId)
+
+	if err != nil {
+		return nil, err
+	}
 
+	return alias.Address(), nil
+}
+
+// getAlias returns alias of namespace from cache of client, namespace is requested when alias isn't cached
+func (ref *NamespaceService) getAlias(ctx context.Context, namespaceId *NamespaceId) (*NamespaceAlias, error) {
+	if alias := ref.client.namespaceCache.Alias(namespaceId); alias != nil {
+		return alias, nil
+	}
+
+	info, err := ref.GetNamespaceInfo(ctx, namespaceId)
 	if err != nil {
 		return nil, err
 	}
 
-	return info.Alias.Address(), nil
+	if info.Alias == nil {
+		return &NamespaceAlias{}, nil
+	}
+
+	return info.Alias, nil
 }
 
 func (ref *NamespaceService) buildNamespaceHierarchy(ctx context.Context, nsInfo *NamespaceInfo) error {
@@ -0,0 +1,163 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultNamespaceCacheTTL is a lifetime of cached alias of Client, alias is refreshed after it even when namespace is active.
+// Without websocket.WatchNamespaceCache it's the only expiry of aliases, since height of cache isn't updated
+const DefaultNamespaceCacheTTL = time.Minute
+
+type namespaceCacheEntry struct {
+	alias     *NamespaceAlias
+	endHeight Height
+	expiresAt time.Time
+}
+
+// NamespaceCache caches aliases of namespaces to mosaics and addresses.
+// Alias is dropped when chain height reaches end height of namespace, when alias transaction of namespace
+// is handled and after TTL when it's positive, because alias can be changed while cache doesn't see transactions.
+// Cache doesn't request chain height by itself, it's moved by SetHeight or HandleBlock, e.g. by websocket.WatchNamespaceCache.
+// Methods of nil cache are no-ops, so nil cache disables caching
+type NamespaceCache struct {
+	lock    sync.RWMutex
+	ttl     time.Duration
+	height  Height
+	entries map[uint64]*namespaceCacheEntry
+}
+
+// NewNamespaceCache returns cache which keeps aliases at most ttl, zero ttl keeps them until namespace expiration
+func NewNamespaceCache(ttl time.Duration) *NamespaceCache {
+	return &NamespaceCache{
+		ttl:     ttl,
+		entries: make(map[uint64]*namespaceCacheEntry),
+	}
+}
+
+// Alias returns cached alias of namespace or nil when it isn't cached or expired
+func (c *NamespaceCache) Alias(namespaceId *NamespaceId) *NamespaceAlias {
+	if c == nil || namespaceId == nil {
+		return nil
+	}
+
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	e, ok := c.entries[namespaceId.Id()]
+	if !ok || c.expired(e, time.Now()) {
+		return nil
+	}
+
+	return e.alias
+}
+
+// Put caches alias of active namespace, namespaces without alias aren't cached
+func (c *NamespaceCache) Put(info *NamespaceInfo) {
+	if c == nil || info == nil || info.NamespaceId == nil || !info.Active || info.Alias == nil || info.Alias.Type == NoneAliasType {
+		return
+	}
+
+	e := &namespaceCacheEntry{
+		alias:     info.Alias,
+		endHeight: info.EndHeight,
+	}
+
+	if c.ttl > 0 {
+		e.expiresAt = time.Now().Add(c.ttl)
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if !c.expired(e, time.Now()) {
+		c.entries[info.NamespaceId.Id()] = e
+	}
+}
+
+// Invalidate drops aliases of namespaces
+func (c *NamespaceCache) Invalidate(namespaceIds ...*NamespaceId) {
+	if c == nil {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for _, id := range namespaceIds {
+		if id != nil {
+			delete(c.entries, id.Id())
+		}
+	}
+}
+
+// Clear drops every cached alias
+func (c *NamespaceCache) Clear() {
+	if c == nil {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.entries = make(map[uint64]*namespaceCacheEntry)
+}
+
+// SetHeight updates known chain height and drops aliases of namespaces which expired at it
+func (c *NamespaceCache) SetHeight(height Height) {
+	if c == nil {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if height <= c.height {
+		return
+	}
+
+	c.height = height
+
+	now := time.Now()
+	for id, e := range c.entries {
+		if c.expired(e, now) {
+			delete(c.entries, id)
+		}
+	}
+}
+
+// HandleBlock updates height of cache, it can be used as websocket block handler
+func (c *NamespaceCache) HandleBlock(block *BlockInfo) {
+	if block != nil {
+		c.SetHeight(block.Height)
+	}
+}
+
+// HandleTransaction drops aliases changed by alias transactions, including ones inside aggregate transaction.
+// It can be used as websocket confirmed transaction handler
+func (c *NamespaceCache) HandleTransaction(tx Transaction) {
+	switch tx := tx.(type) {
+	case *MosaicAliasTransaction:
+		c.Invalidate(tx.NamespaceId)
+	case *AddressAliasTransaction:
+		c.Invalidate(tx.NamespaceId)
+	case *AggregateTransaction:
+		for _, inner := range tx.InnerTransactions {
+			c.HandleTransaction(inner)
+		}
+	}
+}
+
+// expired returns true when namespace of entry isn't active at known height or entry outlived TTL,
+// zero or negative end height means that namespace never expires
+func (c *NamespaceCache) expired(e *namespaceCacheEntry, now time.Time) bool {
+	if e.endHeight > 0 && c.height >= e.endHeight {
+		return true
+	}
+
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
@@ -0,0 +1,98 @@
+package sdk
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamespaceCache(t *testing.T) {
+	mosaicId, err := NewMosaicId(0x26514E2A1EF33824)
+	assert.Nil(t, err)
+
+	info := &NamespaceInfo{
+		NamespaceId: testNamespaceId,
+		Active:      true,
+		Alias:       &NamespaceAlias{mosaicId: mosaicId, Type: MosaicAliasType},
+		EndHeight:   100,
+	}
+
+	cache := NewNamespaceCache(0)
+	cache.Put(info)
+	assert.Equal(t, info.Alias, cache.Alias(testNamespaceId))
+
+	// alias of namespace expires with namespace
+	cache.SetHeight(99)
+	assert.NotNil(t, cache.Alias(testNamespaceId))
+	cache.HandleBlock(&BlockInfo{Height: 100})
+	assert.Nil(t, cache.Alias(testNamespaceId))
+
+	// expired namespaces aren't cached
+	cache.Put(info)
+	assert.Nil(t, cache.Alias(testNamespaceId))
+
+	info.EndHeight = 200
+	cache.Put(info)
+	assert.NotNil(t, cache.Alias(testNamespaceId))
+
+	aliasTx, err := NewMosaicAliasTransaction(NewDeadline(time.Hour), mosaicId, testNamespaceId, AliasUnlink, PublicTest)
+	assert.Nil(t, err)
+	aggTx, err := NewCompleteAggregateTransaction(NewDeadline(time.Hour), []Transaction{aliasTx}, PublicTest)
+	assert.Nil(t, err)
+
+	cache.HandleTransaction(aggTx)
+	assert.Nil(t, cache.Alias(testNamespaceId))
+
+	// namespaces without alias aren't cached
+	cache.Put(&NamespaceInfo{NamespaceId: testNamespaceId, Active: true, Alias: &NamespaceAlias{}})
+	assert.Nil(t, cache.Alias(testNamespaceId))
+
+	cache = NewNamespaceCache(time.Millisecond)
+	cache.Put(info)
+	time.Sleep(2 * time.Millisecond)
+	assert.Nil(t, cache.Alias(testNamespaceId))
+
+	// nil cache disables caching
+	var disabled *NamespaceCache
+	disabled.Put(info)
+	assert.Nil(t, disabled.Alias(testNamespaceId))
+}
+
+func TestNamespaceService_GetLinkedMosaicId_Cached(t *testing.T) {
+	mock := newSdkMock(0)
+	defer mock.Close()
+
+	requests := 0
+	mock.AddHandler(fmt.Sprintf(namespaceRoute, testNamespaceId.toHexString()), func(resp http.ResponseWriter, req *http.Request) {
+		requests++
+		fmt.Fprint(resp, tplInfo)
+	})
+	mock.AddHandler(fmt.Sprintf(offersByMosaicRoute, SellOffer.String(), ""), func(resp http.ResponseWriter, req *http.Request) {
+		fmt.Fprint(resp, "[]")
+	})
+
+	client := mock.getPublicTestClientUnsafe()
+
+	mosaicId, err := client.Namespace.GetLinkedMosaicId(ctx, testNamespaceId)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64DTO{1382215848, 1583663204}.toUint64(), mosaicId.Id())
+
+	_, err = client.Exchange.GetExchangeOfferByAssetId(ctx, testNamespaceId, SellOffer)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, requests)
+
+	client.NamespaceCache().Invalidate(testNamespaceId)
+	_, err = client.Namespace.GetLinkedMosaicId(ctx, testNamespaceId)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, requests)
+
+	client.SetNamespaceCache(nil)
+	_, err = client.Namespace.GetLinkedMosaicId(ctx, testNamespaceId)
+	assert.Nil(t, err)
+	_, err = client.Namespace.GetLinkedMosaicId(ctx, testNamespaceId)
+	assert.Nil(t, err)
+	assert.Equal(t, 4, requests)
+}
@@ -0,0 +1,151 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"context"
+	"strings"
+)
+
+// RegisterNamespacePath builds aggregate transaction which registers missing levels of dotted namespace name like
+// 'root.child.grandchild' and links the leaf to address or mosaic of options. Existing levels are requested with
+// GetNamespaceInfo and skipped, expired root is registered again for duration. Aggregate isn't signed or announced
+func (ref *NamespaceService) RegisterNamespacePath(ctx context.Context, name string, duration Duration, opts *NamespacePathOptions) (*NamespacePathRegistration, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
+	path, err := GenerateNamespacePath(name)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := strings.Split(name, ".")
+
+	r := &NamespacePathRegistration{
+		Path:          path,
+		Existing:      make([]*NamespaceInfo, len(path)),
+		Registrations: make([]*RegisterNamespaceTransaction, 0, len(path)),
+		Aliases:       make([]Transaction, 0, 2),
+	}
+
+	// children can't exist without parent, so levels below missing one aren't requested
+	for i, id := range path {
+		info, err := ref.GetNamespaceInfo(ctx, id)
+		if isNotFoundError(err) {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if info.Owner == nil || info.Owner.PublicKey != opts.Owner.PublicKey {
+			return nil, ErrNamespaceNotOwned
+		}
+
+		r.Existing[i] = info
+	}
+
+	for i, id := range path {
+		existing := r.Existing[i]
+
+		var tx *RegisterNamespaceTransaction
+		switch {
+		case i == 0 && (existing == nil || !existing.Active):
+			if duration <= 0 {
+				return nil, ErrArgumentNotValid
+			}
+
+			tx, err = ref.client.NewRegisterRootNamespaceTransaction(opts.deadline(), parts[i], duration)
+		case existing == nil:
+			tx, err = ref.client.NewRegisterSubNamespaceTransaction(opts.deadline(), parts[i], path[i-1])
+		default:
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if tx.NamespaceId.Id() != id.Id() {
+			return nil, ErrInvalidNamespaceName
+		}
+
+		r.Registrations = append(r.Registrations, tx)
+	}
+
+	if err := ref.linkPathLeaf(r, opts); err != nil {
+		return nil, err
+	}
+
+	if r.IsEmpty() {
+		return r, nil
+	}
+
+	txs := make([]Transaction, 0, len(r.Registrations)+len(r.Aliases))
+	for _, tx := range r.Registrations {
+		txs = append(txs, tx)
+	}
+
+	txs = append(txs, r.Aliases...)
+
+	for _, tx := range txs {
+		tx.GetAbstractTransaction().ToAggregate(opts.Owner)
+	}
+
+	r.Aggregate, err = ref.client.NewCompleteAggregateTransaction(opts.deadline(), txs)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// linkPathLeaf adds alias transactions of options to registration, previous alias of leaf is unlinked before
+func (ref *NamespaceService) linkPathLeaf(r *NamespacePathRegistration, opts *NamespacePathOptions) error {
+	alias := opts.alias()
+	if alias == nil {
+		return nil
+	}
+
+	leafId := r.Path[len(r.Path)-1]
+
+	if leaf := r.Existing[len(r.Path)-1]; leaf != nil && leaf.Alias != nil {
+		if sameAlias(leaf.Alias, alias) {
+			return nil
+		}
+
+		tx, err := ref.aliasTransaction(opts.deadline(), leaf.Alias, leafId, AliasUnlink)
+		if err != nil {
+			return err
+		}
+
+		if tx != nil {
+			r.Aliases = append(r.Aliases, tx)
+		}
+	}
+
+	tx, err := ref.aliasTransaction(opts.deadline(), alias, leafId, AliasLink)
+	if err != nil {
+		return err
+	}
+
+	r.Aliases = append(r.Aliases, tx)
+
+	return nil
+}
+
+// aliasTransaction returns transaction which links or unlinks alias, it is nil for empty alias
+func (ref *NamespaceService) aliasTransaction(deadline *Deadline, alias *NamespaceAlias, namespaceId *NamespaceId, action AliasActionType) (Transaction, error) {
+	switch alias.Type {
+	case AddressAliasType:
+		return ref.client.NewAddressAliasTransaction(deadline, alias.Address(), namespaceId, action)
+	case MosaicAliasType:
+		return ref.client.NewMosaicAliasTransaction(deadline, alias.MosaicId(), namespaceId, action)
+	default:
+		return nil, nil
+	}
+}
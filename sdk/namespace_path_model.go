@@ -0,0 +1,98 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"time"
+
+	"github.com/proximax-storage/go-xpx-utils/str"
+)
+
+// NamespacePathOptions configures registration of namespace path
+type NamespacePathOptions struct {
+	// Owner signs aggregate of registrations, existing levels of path should belong to it
+	Owner *PublicAccount
+	// Address is linked to the leaf namespace when it isn't nil
+	Address *Address
+	// MosaicId is linked to the leaf namespace when it isn't nil
+	MosaicId *MosaicId
+	// Deadline of transactions, default is one hour
+	Deadline time.Duration
+}
+
+func (o *NamespacePathOptions) validate() error {
+	if o == nil || o.Owner == nil {
+		return ErrNilAccount
+	}
+
+	if o.Address != nil && o.MosaicId != nil {
+		return ErrInvalidNamespaceLink
+	}
+
+	return nil
+}
+
+func (o *NamespacePathOptions) deadline() *Deadline {
+	if o.Deadline <= 0 {
+		return NewDeadline(time.Hour)
+	}
+
+	return NewDeadline(o.Deadline)
+}
+
+// alias returns alias requested by options or nil when leaf isn't linked
+func (o *NamespacePathOptions) alias() *NamespaceAlias {
+	switch {
+	case o.Address != nil:
+		return &NamespaceAlias{address: o.Address, Type: AddressAliasType}
+	case o.MosaicId != nil:
+		return &NamespaceAlias{mosaicId: o.MosaicId, Type: MosaicAliasType}
+	default:
+		return nil
+	}
+}
+
+// NamespacePathRegistration contains transactions which register missing levels of namespace path and link its leaf
+type NamespacePathRegistration struct {
+	Path []*NamespaceId
+	// Existing contains infos of levels registered before, it is nil for missing levels
+	Existing []*NamespaceInfo
+	// Registrations register missing levels from root to leaf
+	Registrations []*RegisterNamespaceTransaction
+	// Aliases unlink previous alias of leaf and link requested one
+	Aliases []Transaction
+	// Aggregate contains every transaction of registration signed by owner, it is nil when path is already registered and linked
+	Aggregate *AggregateTransaction
+}
+
+// IsEmpty returns true when path is already registered and linked
+func (r *NamespacePathRegistration) IsEmpty() bool {
+	return len(r.Registrations) == 0 && len(r.Aliases) == 0
+}
+
+func (r *NamespacePathRegistration) String() string {
+	return str.StructToString(
+		"NamespacePathRegistration",
+		str.NewField("Path", str.StringPattern, r.Path),
+		str.NewField("Registrations", str.StringPattern, r.Registrations),
+		str.NewField("Aliases", str.StringPattern, r.Aliases),
+	)
+}
+
+// sameAlias returns true when aliases link namespace to the same address or mosaic
+func sameAlias(a, b *NamespaceAlias) bool {
+	if a == nil || b == nil || a.Type != b.Type {
+		return false
+	}
+
+	switch a.Type {
+	case AddressAliasType:
+		return a.address != nil && b.address != nil && a.address.Address == b.address.Address
+	case MosaicAliasType:
+		return a.mosaicId != nil && b.mosaicId != nil && a.mosaicId.Id() == b.mosaicId.Id()
+	default:
+		return false
+	}
+}
@@ -0,0 +1,129 @@
+package sdk
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/proximax-storage/go-xpx-utils/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamespaceService_RegisterNamespacePath(t *testing.T) {
+	mockServer := newSdkMock(0)
+	defer mockServer.Close()
+
+	owner, err := NewAccount(PublicTest, &Hash{})
+	assert.Nil(t, err)
+
+	path, err := GenerateNamespacePath("root.child.leaf")
+	assert.Nil(t, err)
+
+	requested := make([]string, 0)
+	mockServer.AddHandler(strings.TrimSuffix(namespaceRoute, "%s"), func(resp http.ResponseWriter, req *http.Request) {
+		requested = append(requested, req.URL.Path)
+
+		if req.URL.Path != fmt.Sprintf(namespaceRoute, path[0].toHexString()) {
+			resp.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(resp, `{"code": "ResourceNotFound", "message": "no resource exists with id"}`)
+			return
+		}
+
+		fmt.Fprint(resp, testRenewalNamespaceJson(owner.PublicAccount, path[0], 10000))
+	})
+
+	client := mockServer.getPublicTestClientUnsafe()
+	client.config.GenerationHash = &Hash{}
+	client.SetNamespaceCache(nil)
+
+	r, err := client.Namespace.RegisterNamespacePath(ctx, "root.child.leaf", 100, &NamespacePathOptions{
+		Owner:   owner.PublicAccount,
+		Address: owner.PublicAccount.Address,
+	})
+	assert.Nil(t, err)
+	// the grandchild isn't requested when the child is missing
+	assert.Len(t, requested, 2)
+	assert.NotNil(t, r.Existing[0])
+	assert.Nil(t, r.Existing[1])
+
+	assert.Len(t, r.Registrations, 2)
+	assert.Equal(t, "child", r.Registrations[0].NamspaceName)
+	assert.Equal(t, path[0], r.Registrations[0].ParentId)
+	assert.Equal(t, path[2], r.Registrations[1].NamespaceId)
+	assert.Len(t, r.Aliases, 1)
+	assert.Equal(t, AliasLink, r.Aliases[0].(*AddressAliasTransaction).ActionType)
+	assert.Len(t, r.Aggregate.InnerTransactions, 3)
+
+	_, err = owner.Sign(r.Aggregate)
+	assert.Nil(t, err)
+
+	// existing root is relinked from mosaic to address
+	mosaicId, err := NewMosaicId(0x26514E2A1EF33824)
+	assert.Nil(t, err)
+
+	r, err = client.Namespace.RegisterNamespacePath(ctx, "root", 100, &NamespacePathOptions{
+		Owner:    owner.PublicAccount,
+		MosaicId: mosaicId,
+	})
+	assert.Nil(t, err)
+	assert.Empty(t, r.Registrations)
+	assert.Len(t, r.Aliases, 1)
+
+	r, err = client.Namespace.RegisterNamespacePath(ctx, "root", 100, &NamespacePathOptions{Owner: owner.PublicAccount})
+	assert.Nil(t, err)
+	assert.True(t, r.IsEmpty())
+	assert.Nil(t, r.Aggregate)
+
+	_, err = client.Namespace.RegisterNamespacePath(ctx, "root.child", 100, &NamespacePathOptions{Owner: testMarketOwner})
+	assert.Equal(t, ErrNamespaceNotOwned, err)
+
+	_, err = client.Namespace.RegisterNamespacePath(ctx, "root", 100, &NamespacePathOptions{
+		Owner:    owner.PublicAccount,
+		Address:  owner.PublicAccount.Address,
+		MosaicId: mosaicId,
+	})
+	assert.Equal(t, ErrInvalidNamespaceLink, err)
+}
+
+func TestNamespaceService_RegisterNamespacePath_Relink(t *testing.T) {
+	mockServer := newSdkMock(0)
+	defer mockServer.Close()
+
+	owner, err := NewAccount(PublicTest, &Hash{})
+	assert.Nil(t, err)
+
+	rootId, err := NewNamespaceIdFromName("root")
+	assert.Nil(t, err)
+
+	linked := strings.Replace(testRenewalNamespaceJson(owner.PublicAccount, rootId, 10000),
+		`"alias": {"type": 0}`, `"alias": {"type": 1, "mosaicId": [1382215848, 1583663204]}`, 1)
+	mockServer.AddRouter(&mock.Router{
+		Path:     fmt.Sprintf(namespaceRoute, rootId.toHexString()),
+		RespBody: linked,
+	})
+
+	client := mockServer.getPublicTestClientUnsafe()
+	client.config.GenerationHash = &Hash{}
+
+	r, err := client.Namespace.RegisterNamespacePath(ctx, "root", 100, &NamespacePathOptions{
+		Owner:   owner.PublicAccount,
+		Address: owner.PublicAccount.Address,
+	})
+	assert.Nil(t, err)
+	assert.Empty(t, r.Registrations)
+	assert.Len(t, r.Aliases, 2)
+	assert.Equal(t, AliasUnlink, r.Aliases[0].(*MosaicAliasTransaction).ActionType)
+	assert.Equal(t, AliasLink, r.Aliases[1].(*AddressAliasTransaction).ActionType)
+
+	// the same alias isn't linked again
+	mosaicId, err := NewMosaicId(uint64DTO{1382215848, 1583663204}.toUint64())
+	assert.Nil(t, err)
+
+	r, err = client.Namespace.RegisterNamespacePath(ctx, "root", 100, &NamespacePathOptions{
+		Owner:    owner.PublicAccount,
+		MosaicId: mosaicId,
+	})
+	assert.Nil(t, err)
+	assert.True(t, r.IsEmpty())
+}
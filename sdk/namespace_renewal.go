@@ -0,0 +1,243 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	namespacePluginSection    = "plugin:catapult.plugins.namespace"
+	rootNamespaceRentalFeeKey = "rootNamespaceRentalFeePerBlock"
+	renewalPageSize           = 100
+)
+
+// GetNamespaceRenewals returns renewal forecasts of root namespaces owned by accounts.
+// Sub namespaces expire with their root, so they aren't renewed separately.
+// Transactions of due renewals are built, but not announced
+func (ref *NamespaceService) GetNamespaceRenewals(ctx context.Context, config *NamespaceRenewalConfig, owners ...*Address) (*NamespaceRenewalPlan, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	infos, err := ref.rootNamespacesOf(ctx, owners)
+	if err != nil {
+		return nil, err
+	}
+
+	height, err := ref.client.Blockchain.GetBlockchainHeight(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	blockTime, err := ref.client.BlockGenerationTime(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	feePerBlock := config.FeePerBlock
+	if feePerBlock == 0 {
+		feePerBlock, err = ref.rootNamespaceRentalFee(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	plan := &NamespaceRenewalPlan{
+		Height:      height,
+		BlockTime:   blockTime,
+		FeePerBlock: feePerBlock,
+		Renewals:    make([]*NamespaceRenewal, 0, len(infos)),
+	}
+
+	if len(infos) == 0 {
+		return plan, nil
+	}
+
+	names, err := ref.rootNamespaceNames(ctx, infos)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, info := range infos {
+		r, err := NewNamespaceRenewal(info, names[info.NamespaceId.Id()], height, blockTime, config, feePerBlock)
+		if err != nil {
+			return nil, err
+		}
+
+		if r.Due {
+			r.Transaction, err = ref.client.NewRegisterRootNamespaceTransaction(config.deadline(), r.Name, r.Duration)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		plan.Renewals = append(plan.Renewals, r)
+	}
+
+	return plan, nil
+}
+
+// rootNamespacesOf returns root namespaces of accounts requesting every page of namespaces
+func (ref *NamespaceService) rootNamespacesOf(ctx context.Context, owners []*Address) ([]*NamespaceInfo, error) {
+	roots := make([]*NamespaceInfo, 0)
+
+	var last *NamespaceId
+	for {
+		infos, err := ref.GetNamespaceInfosFromAccounts(ctx, owners, last, renewalPageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, info := range infos {
+			if info.TypeSpace == Root {
+				roots = append(roots, info)
+			}
+		}
+
+		if len(infos) < renewalPageSize {
+			return roots, nil
+		}
+
+		last = infos[len(infos)-1].NamespaceId
+	}
+}
+
+func (ref *NamespaceService) rootNamespaceNames(ctx context.Context, infos []*NamespaceInfo) (map[uint64]string, error) {
+	ids := make([]*NamespaceId, len(infos))
+	for i, info := range infos {
+		ids[i] = info.NamespaceId
+	}
+
+	nsNames, err := ref.GetNamespaceNames(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[uint64]string, len(nsNames))
+	for _, n := range nsNames {
+		names[n.NamespaceId.Id()] = n.FullName
+	}
+
+	for _, id := range ids {
+		if names[id.Id()] == "" {
+			return nil, ErrNamespaceNameUnknown
+		}
+	}
+
+	return names, nil
+}
+
+// rootNamespaceRentalFee returns rental fee of root namespace per block from network config, it is zero when config doesn't define it
+func (ref *NamespaceService) rootNamespaceRentalFee(ctx context.Context) (Amount, error) {
+	cfg, err := ref.client.Network.GetNetworkConfig(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	if pl, ok := cfg.NetworkConfig.Sections[namespacePluginSection]; ok {
+		if v, ok := pl.Fields[rootNamespaceRentalFeeKey]; ok {
+			// catapult config separates digit groups with apostrophes
+			fee, err := strconv.ParseInt(strings.Replace(v.Value, "'", "", -1), 10, 64)
+			if err != nil {
+				return 0, err
+			}
+
+			return Amount(fee), nil
+		}
+	}
+
+	return 0, nil
+}
+
+// NamespaceRenewer renews root namespaces of owners before they expire
+type NamespaceRenewer struct {
+	client *Client
+	config *NamespaceRenewalConfig
+	owners map[string]*Account
+}
+
+// NewNamespaceRenewer returns renewer of root namespaces owned by accounts, every renewal is signed by owner of namespace
+func NewNamespaceRenewer(client *Client, config *NamespaceRenewalConfig, owners ...*Account) (*NamespaceRenewer, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	if len(owners) == 0 {
+		return nil, ErrInvalidRenewalConfig
+	}
+
+	r := &NamespaceRenewer{
+		client: client,
+		config: config,
+		owners: make(map[string]*Account, len(owners)),
+	}
+
+	for _, o := range owners {
+		if o == nil {
+			return nil, ErrNilAccount
+		}
+
+		r.owners[o.PublicAccount.PublicKey] = o
+	}
+
+	return r, nil
+}
+
+// Plan returns renewal forecasts of namespaces of owners
+func (r *NamespaceRenewer) Plan(ctx context.Context) (*NamespaceRenewalPlan, error) {
+	addresses := make([]*Address, 0, len(r.owners))
+	for _, o := range r.owners {
+		addresses = append(addresses, o.PublicAccount.Address)
+	}
+
+	return r.client.Namespace.GetNamespaceRenewals(ctx, r.config, addresses...)
+}
+
+// Renew announces due renewals of plan and waits for their confirmation
+func (r *NamespaceRenewer) Renew(ctx context.Context) (*NamespaceRenewalPlan, error) {
+	plan, err := r.Plan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, renewal := range plan.Due() {
+		owner, ok := r.owners[renewal.Namespace.Owner.PublicKey]
+		if !ok {
+			return nil, ErrNilAccount
+		}
+
+		signed, err := owner.Sign(renewal.Transaction)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := r.client.Transaction.announceAndWait(ctx, signed, r.config.pollInterval()); err != nil {
+			return nil, err
+		}
+	}
+
+	return plan, nil
+}
+
+// Run renews namespaces every interval until context is done or renewal fails.
+// Every plan with due renewals is passed to onRenew when it isn't nil
+func (r *NamespaceRenewer) Run(ctx context.Context, interval time.Duration, onRenew func(*NamespaceRenewalPlan)) error {
+	return poll(ctx, interval, func() (bool, error) {
+		plan, err := r.Renew(ctx)
+		if err != nil {
+			return false, err
+		}
+
+		if onRenew != nil && len(plan.Due()) > 0 {
+			onRenew(plan)
+		}
+
+		return false, nil
+	})
+}
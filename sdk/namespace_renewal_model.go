@@ -0,0 +1,151 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"time"
+
+	"github.com/proximax-storage/go-xpx-utils/str"
+)
+
+// NamespaceRenewalConfig configures renewal of root namespaces
+type NamespaceRenewalConfig struct {
+	// Margin is a safety margin, renewal is due when namespace expires within it
+	Margin time.Duration
+	// Duration is a number of blocks added to namespace by renewal
+	Duration Duration
+	// FeePerBlock is a rental fee of root namespace per block, fee of network config is used when it is zero
+	FeePerBlock Amount
+	// Deadline of renewal transactions, default is one hour
+	Deadline time.Duration
+	// PollInterval is an interval of checks of renewal confirmation, default is one second
+	PollInterval time.Duration
+}
+
+func (c *NamespaceRenewalConfig) validate() error {
+	if c == nil || c.Duration <= 0 || c.Margin < 0 || c.FeePerBlock < 0 {
+		return ErrInvalidRenewalConfig
+	}
+
+	return nil
+}
+
+func (c *NamespaceRenewalConfig) deadline() *Deadline {
+	if c.Deadline <= 0 {
+		return NewDeadline(time.Hour)
+	}
+
+	return NewDeadline(c.Deadline)
+}
+
+func (c *NamespaceRenewalConfig) pollInterval() time.Duration {
+	if c.PollInterval <= 0 {
+		return time.Second
+	}
+
+	return c.PollInterval
+}
+
+// NamespaceRenewal is a renewal forecast of root namespace
+type NamespaceRenewal struct {
+	Namespace *NamespaceInfo
+	Name      string
+	// RemainingBlocks is a number of blocks until end height of namespace, it is negative when namespace is expired
+	RemainingBlocks Duration
+	// RemainingTime is an estimated time until namespace expiration
+	RemainingTime time.Duration
+	Due           bool
+	// Duration is a number of blocks added to namespace by renewal
+	Duration Duration
+	// Cost is a rental fee of renewal in XPX
+	Cost Amount
+	// Transaction renews namespace, it is built by NamespaceService only when renewal is due
+	Transaction *RegisterNamespaceTransaction
+}
+
+func (r *NamespaceRenewal) String() string {
+	return str.StructToString(
+		"NamespaceRenewal",
+		str.NewField("NamespaceId", str.StringPattern, r.Namespace.NamespaceId),
+		str.NewField("Name", str.StringPattern, r.Name),
+		str.NewField("RemainingBlocks", str.StringPattern, r.RemainingBlocks),
+		str.NewField("RemainingTime", str.StringPattern, r.RemainingTime),
+		str.NewField("Due", str.BooleanPattern, r.Due),
+		str.NewField("Duration", str.StringPattern, r.Duration),
+		str.NewField("Cost", str.StringPattern, r.Cost),
+	)
+}
+
+// NewNamespaceRenewal returns forecast of root namespace with passed name at chain height.
+// blockTime is a block generation time used to estimate time until namespace expiration
+func NewNamespaceRenewal(info *NamespaceInfo, name string, height Height, blockTime time.Duration, config *NamespaceRenewalConfig, feePerBlock Amount) (*NamespaceRenewal, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	if info == nil || info.TypeSpace != Root {
+		return nil, ErrArgumentNotValid
+	}
+
+	remaining := Duration(info.EndHeight - height)
+
+	r := &NamespaceRenewal{
+		Namespace:       info,
+		Name:            name,
+		RemainingBlocks: remaining,
+		RemainingTime:   time.Duration(remaining) * blockTime,
+		Duration:        config.Duration,
+		Cost:            feePerBlock * Amount(config.Duration),
+	}
+
+	// zero or negative end height means that namespace never expires
+	if info.EndHeight <= 0 || r.RemainingTime > config.Margin {
+		return r, nil
+	}
+
+	r.Due = true
+
+	return r, nil
+}
+
+// NamespaceRenewalPlan is a renewal forecast of root namespaces of accounts
+type NamespaceRenewalPlan struct {
+	Height      Height
+	BlockTime   time.Duration
+	FeePerBlock Amount
+	Renewals    []*NamespaceRenewal
+}
+
+// Due returns renewals which should be made now
+func (p *NamespaceRenewalPlan) Due() []*NamespaceRenewal {
+	due := make([]*NamespaceRenewal, 0)
+	for _, r := range p.Renewals {
+		if r.Due {
+			due = append(due, r)
+		}
+	}
+
+	return due
+}
+
+// Cost returns rental fee of due renewals in XPX
+func (p *NamespaceRenewalPlan) Cost() Amount {
+	var cost Amount
+	for _, r := range p.Due() {
+		cost += r.Cost
+	}
+
+	return cost
+}
+
+func (p *NamespaceRenewalPlan) String() string {
+	return str.StructToString(
+		"NamespaceRenewalPlan",
+		str.NewField("Height", str.StringPattern, p.Height),
+		str.NewField("BlockTime", str.StringPattern, p.BlockTime),
+		str.NewField("FeePerBlock", str.StringPattern, p.FeePerBlock),
+		str.NewField("Renewals", str.StringPattern, p.Renewals),
+	)
+}
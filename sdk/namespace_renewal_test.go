@@ -0,0 +1,149 @@
+package sdk
+
+import (
+	"encoding/base32"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/proximax-storage/go-xpx-utils/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+const testRenewalConfigJson = `{
+	"networkConfig": {
+		"height": [1000, 0],
+		"networkConfig": "[chain]\n\nblockGenerationTargetTime = 10s\n\n[plugin:catapult.plugins.namespace]\n\nrootNamespaceRentalFeePerBlock = 1'000\n\n",
+		"supportedEntityVersions": "{\n    \"entities\": []\n}"
+	}
+}`
+
+func testRenewalNamespaceJson(owner *PublicAccount, id *NamespaceId, endHeight int64) string {
+	raw, _ := base32.StdEncoding.DecodeString(owner.Address.Address)
+
+	return fmt.Sprintf(`{
+		"meta": {"active": true, "index": 0, "id": "5B55E02EACCB7B00015DB6EB"},
+		"namespace": {
+			"namespaceId": [%d, %d],
+			"type": 0,
+			"depth": 1,
+			"level0": [%d, %d],
+			"alias": {"type": 0},
+			"owner": "%s",
+			"ownerAddress": "%s",
+			"startHeight": [1, 0],
+			"endHeight": [%d, %d]
+		}
+	}`, uint32(id.Id()), uint32(id.Id()>>32), uint32(id.Id()), uint32(id.Id()>>32),
+		owner.PublicKey, strings.ToUpper(hex.EncodeToString(raw)), uint32(endHeight), uint32(uint64(endHeight)>>32))
+}
+
+func TestNewNamespaceRenewal(t *testing.T) {
+	config := &NamespaceRenewalConfig{Margin: time.Hour, Duration: 100}
+	info := &NamespaceInfo{NamespaceId: testNamespaceId, TypeSpace: Root, EndHeight: 1300}
+
+	r, err := NewNamespaceRenewal(info, "renew", 1000, 15*time.Second, config, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, Duration(300), r.RemainingBlocks)
+	assert.Equal(t, 75*time.Minute, r.RemainingTime)
+	assert.False(t, r.Due)
+	assert.Equal(t, Amount(1000), r.Cost)
+
+	r, err = NewNamespaceRenewal(info, "renew", 1100, 15*time.Second, config, 10)
+	assert.Nil(t, err)
+	assert.True(t, r.Due)
+
+	// namespace can still be renewed by owner after expiration
+	r, err = NewNamespaceRenewal(info, "renew", 1400, 15*time.Second, config, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, Duration(-100), r.RemainingBlocks)
+	assert.True(t, r.Due)
+
+	info.EndHeight = -1
+	r, err = NewNamespaceRenewal(info, "renew", 1400, 15*time.Second, config, 10)
+	assert.Nil(t, err)
+	assert.False(t, r.Due)
+
+	_, err = NewNamespaceRenewal(info, "renew", 1000, time.Second, &NamespaceRenewalConfig{}, 10)
+	assert.Equal(t, ErrInvalidRenewalConfig, err)
+
+	_, err = NewNamespaceRenewal(&NamespaceInfo{TypeSpace: Sub}, "renew.sub", 1000, time.Second, config, 10)
+	assert.Equal(t, ErrArgumentNotValid, err)
+}
+
+func TestNamespaceRenewer_Renew(t *testing.T) {
+	mockServer := newSdkMock(0)
+	defer mockServer.Close()
+
+	owner, err := NewAccount(PublicTest, &Hash{})
+	assert.Nil(t, err)
+
+	dueId, err := NewNamespaceIdFromName("renew")
+	assert.Nil(t, err)
+	laterId, err := NewNamespaceIdFromName("later")
+	assert.Nil(t, err)
+
+	announced := make([]string, 0)
+
+	mockServer.AddRouter(&mock.Router{
+		Path: namespacesFromAccountsRoute,
+		RespBody: "[" + testRenewalNamespaceJson(owner.PublicAccount, dueId, 1300) + ", " +
+			testRenewalNamespaceJson(owner.PublicAccount, laterId, 100000) + "]",
+	})
+	mockServer.AddRouter(&mock.Router{
+		Path: namespaceNamesRoute,
+		RespBody: fmt.Sprintf(`[{"namespaceId": [%d, %d], "name": "renew"}, {"namespaceId": [%d, %d], "name": "later"}]`,
+			uint32(dueId.Id()), uint32(dueId.Id()>>32), uint32(laterId.Id()), uint32(laterId.Id()>>32)),
+	})
+	mockServer.AddRouter(&mock.Router{
+		Path:     blockHeightRoute,
+		RespBody: `{"height": [1000, 0]}`,
+	})
+	mockServer.AddRouter(&mock.Router{
+		Path:     fmt.Sprintf(configRoute, Height(1000)),
+		RespBody: testRenewalConfigJson,
+	})
+	mockServer.AddHandler(transactionsRoute, func(resp http.ResponseWriter, req *http.Request) {
+		announced = append(announced, req.URL.Path)
+
+		resp.WriteHeader(http.StatusAccepted)
+		fmt.Fprint(resp, `{"message": "packet 9 was pushed to the network via /transaction"}`)
+	})
+	mockServer.AddHandler(strings.TrimSuffix(transactionStatusByIdRoute, "%s"), func(resp http.ResponseWriter, req *http.Request) {
+		hash := req.URL.Path[strings.LastIndex(req.URL.Path, "/")+1:]
+		fmt.Fprintf(resp, `{"group": "confirmed", "status": "Success", "hash": "%s", "deadline": [1, 0], "height": [1, 0]}`, hash)
+	})
+
+	client := mockServer.getPublicTestClientUnsafe()
+	client.config.GenerationHash = &Hash{}
+
+	_, err = NewNamespaceRenewer(client, &NamespaceRenewalConfig{Duration: 100})
+	assert.Equal(t, ErrInvalidRenewalConfig, err)
+
+	// "renew" expires in 300 blocks of 10s
+	renewer, err := NewNamespaceRenewer(client, &NamespaceRenewalConfig{
+		Margin:       time.Hour,
+		Duration:     500,
+		PollInterval: time.Millisecond,
+	}, owner)
+	assert.Nil(t, err)
+
+	plan, err := renewer.Renew(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, Height(1000), plan.Height)
+	assert.Equal(t, 10*time.Second, plan.BlockTime)
+	assert.Equal(t, Amount(1000), plan.FeePerBlock)
+	assert.Len(t, plan.Renewals, 2)
+
+	due := plan.Due()
+	assert.Len(t, due, 1)
+	assert.Equal(t, "renew", due[0].Name)
+	assert.Equal(t, Duration(300), due[0].RemainingBlocks)
+	assert.Equal(t, "renew", due[0].Transaction.NamspaceName)
+	assert.Equal(t, Duration(500), due[0].Transaction.Duration)
+	assert.Equal(t, Amount(500000), plan.Cost())
+	assert.Len(t, announced, 1)
+}
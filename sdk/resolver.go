@@ -21,23 +21,34 @@ func (ref *ResolverService) GetMosaicInfoByAssetId(ctx context.Context, assetId
 		return nil, ErrNilAssetId
 	}
 
+	mosaicId, err := ref.ResolveMosaicId(ctx, assetId)
+	if err != nil {
+		return nil, err
+	}
+
+	return ref.MosaicService.GetMosaicInfo(ctx, mosaicId)
+}
+
+// ResolveMosaicId returns mosaic id of asset, alias of namespace is taken from namespace cache of client when it's cached
+func (ref *ResolverService) ResolveMosaicId(ctx context.Context, assetId AssetId) (*MosaicId, error) {
+	if assetId == nil {
+		return nil, ErrNilAssetId
+	}
+
 	switch assetId.Type() {
 	case NamespaceAssetIdType:
-		namespaceId := assetId.(*NamespaceId)
-		namespaceInfo, err := ref.NamespaceService.GetNamespaceInfo(ctx, namespaceId)
-
+		mosaicId, err := ref.NamespaceService.GetLinkedMosaicId(ctx, assetId.(*NamespaceId))
 		if err != nil {
 			return nil, err
 		}
 
-		if namespaceInfo.Alias == nil || namespaceInfo.Alias.MosaicId() == nil {
+		if mosaicId == nil {
 			return nil, errors.New("Namespace is not aliased to Mosaic")
 		}
 
-		return ref.MosaicService.GetMosaicInfo(ctx, namespaceInfo.Alias.MosaicId())
+		return mosaicId, nil
 	case MosaicAssetIdType:
-		mosaicId := assetId.(*MosaicId)
-		return ref.MosaicService.GetMosaicInfo(ctx, mosaicId)
+		return assetId.(*MosaicId), nil
 	}
 
 	return nil, ErrUnknownBlockchainType
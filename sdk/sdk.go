@@ -152,6 +152,8 @@ type Client struct {
 	client *http.Client // HTTP client used to communicate with the API.
 	config *Config
 	common service // Reuse a single struct instead of allocating one for each service on the heap.
+	// namespaceCache caches aliases of namespaces resolved by services
+	namespaceCache *NamespaceCache
 	// Services for communicating to the Catapult REST APIs
 	Blockchain    *BlockchainService
 	Exchange      *ExchangeService
@@ -175,7 +177,9 @@ type service struct {
 }
 
 // returns catapult http.Client from passed existing client and configuration
-// if passed client is nil, http.DefaultClient will be used
+// if passed client is nil, http.DefaultClient will be used.
+// Client caches namespace aliases for DefaultNamespaceCacheTTL. Cache learns chain height only from
+// websocket.WatchNamespaceCache, without it aliases of expired namespaces are dropped only after TTL
 func NewClient(httpClient *http.Client, conf *Config) *Client {
 	if httpClient == nil {
 		var netTransport = &http.Transport{
@@ -194,7 +198,7 @@ func NewClient(httpClient *http.Client, conf *Config) *Client {
 		}
 	}
 
-	c := &Client{client: httpClient, config: conf}
+	c := &Client{client: httpClient, config: conf, namespaceCache: NewNamespaceCache(DefaultNamespaceCacheTTL)}
 	c.common.client = c
 	c.Blockchain = (*BlockchainService)(&c.common)
 	c.Mosaic = (*MosaicService)(&c.common)
@@ -223,6 +227,17 @@ func (c *Client) GenerationHash() *Hash {
 	return c.config.GenerationHash
 }
 
+// NamespaceCache returns cache of namespace aliases used by services
+func (c *Client) NamespaceCache() *NamespaceCache {
+	return c.namespaceCache
+}
+
+// SetNamespaceCache replaces cache of namespace aliases, nil disables caching.
+// It should be called before client is used concurrently
+func (c *Client) SetNamespaceCache(cache *NamespaceCache) {
+	c.namespaceCache = cache
+}
+
 //BlockGenerationTime gets value from config. If value not found returns default value - 15s
 func (c *Client) BlockGenerationTime(ctx context.Context) (time.Duration, error) {
 	cfg, err := c.Network.GetNetworkConfig(ctx)
@@ -612,6 +627,20 @@ func (c *Client) NewTransferTransaction(deadline *Deadline, recipient *Address,
 	return tx, err
 }
 
+// NewTransferTransactionWithAmounts returns transfer of mosaic amounts, like ones parsed from decimal strings
+func (c *Client) NewTransferTransactionWithAmounts(deadline *Deadline, recipient *Address, amounts []*MosaicAmount, message Message) (*TransferTransaction, error) {
+	mosaics := make([]*Mosaic, len(amounts))
+	for i, a := range amounts {
+		if a == nil {
+			return nil, ErrNilMosaic
+		}
+
+		mosaics[i] = a.Mosaic()
+	}
+
+	return c.NewTransferTransaction(deadline, recipient, mosaics, message)
+}
+
 func (c *Client) NewTransferTransactionWithNamespace(deadline *Deadline, recipient *NamespaceId, mosaics []*Mosaic, message Message) (*TransferTransaction, error) {
 	tx, err := NewTransferTransactionWithNamespace(deadline, recipient, mosaics, message, c.config.NetworkType)
 	if tx != nil {
@@ -704,6 +733,15 @@ func (c *Client) NewDriveFileSystemTransaction(deadline *Deadline, driveKey stri
 	return tx, err
 }
 
+func (c *Client) NewDriveFileSystemTransactionsFromTree(deadline *Deadline, drive *Drive, tree *DriveTree, maxTransactionSize int) ([]*DriveFileSystemTransaction, error) {
+	txs, err := NewDriveFileSystemTransactionsFromTree(deadline, drive, tree, maxTransactionSize, c.config.NetworkType)
+	for _, tx := range txs {
+		c.modifyTransaction(tx)
+	}
+
+	return txs, err
+}
+
 func (c *Client) NewFilesDepositTransaction(deadline *Deadline, driveKey *PublicAccount, files []*File) (*FilesDepositTransaction, error) {
 	tx, err := NewFilesDepositTransaction(deadline, driveKey, files, c.config.NetworkType)
 	if tx != nil {
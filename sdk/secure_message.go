@@ -0,0 +1,343 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"io"
+
+	xpxcrypto "github.com/proximax-storage/go-xpx-crypto"
+	"golang.org/x/crypto/hkdf"
+)
+
+type SecureMessageVersion uint8
+
+const (
+	// SecureMessageV1 is the legacy AES-CBC envelope produced by NewSecureMessageFromPlaintText
+	SecureMessageV1 SecureMessageVersion = iota + 1
+	// SecureMessageV2 is the AES-GCM envelope with HKDF derived keys and multiple recipients
+	SecureMessageV2
+)
+
+const (
+	secureMessagePublicKeySize  = 32
+	secureMessageSaltSize       = 32
+	secureMessageContentKeySize = 32
+	secureMessageNonceSize      = 12
+	secureMessageTagSize        = 16
+	secureMessageMaxRecipients  = 255
+	// recipient public key | nonce | wrapped content key with tag
+	secureMessageRecipientSize = secureMessagePublicKeySize + secureMessageNonceSize + secureMessageContentKeySize + secureMessageTagSize
+)
+
+var (
+	// secureMessageMagic prefixes every versioned envelope. Legacy payloads start with a random salt,
+	// so the magic is only a hint and the envelope is additionally authenticated by AES-GCM.
+	secureMessageMagic    = []byte{0x58, 0x53, 0x4D} // "XSM"
+	secureMessageHkdfInfo = []byte("xpx secure message v2")
+)
+
+// secureMessageRecipient is an entry of SecureMessageV2 envelope.
+// It holds the content key encrypted with a key derived for a single recipient
+type secureMessageRecipient struct {
+	PublicKey  []byte
+	Nonce      []byte
+	WrappedKey []byte
+}
+
+// secureMessageEnvelope is a parsed SecureMessageV2 payload
+// magic(3) | version(1) | recipients count(1) | salt(32) | recipients | nonce(12) | ciphertext with tag
+type secureMessageEnvelope struct {
+	Salt       []byte
+	Recipients []*secureMessageRecipient
+	Nonce      []byte
+	Ciphertext []byte
+}
+
+// preamble returns magic, version, recipients count and salt of envelope
+func (e *secureMessageEnvelope) preamble(count int) []byte {
+	var b bytes.Buffer
+
+	b.Write(secureMessageMagic)
+	b.WriteByte(byte(SecureMessageV2))
+	b.WriteByte(byte(count))
+	b.Write(e.Salt)
+
+	return b.Bytes()
+}
+
+// wrapAdditionalData authenticates preamble together with public key of recipient which content key is wrapped for
+func (e *secureMessageEnvelope) wrapAdditionalData(count int, publicKey []byte) []byte {
+	return append(e.preamble(count), publicKey...)
+}
+
+// header returns every byte of envelope before content nonce, it is authenticated as additional data of content
+func (e *secureMessageEnvelope) header() []byte {
+	b := bytes.NewBuffer(e.preamble(len(e.Recipients)))
+
+	for _, r := range e.Recipients {
+		b.Write(r.PublicKey)
+		b.Write(r.Nonce)
+		b.Write(r.WrappedKey)
+	}
+
+	return b.Bytes()
+}
+
+func (e *secureMessageEnvelope) bytes() []byte {
+	b := bytes.NewBuffer(e.header())
+
+	b.Write(e.Nonce)
+	b.Write(e.Ciphertext)
+
+	return b.Bytes()
+}
+
+func (e *secureMessageEnvelope) recipient(publicKey []byte) *secureMessageRecipient {
+	for _, r := range e.Recipients {
+		if bytes.Equal(r.PublicKey, publicKey) {
+			return r
+		}
+	}
+
+	return nil
+}
+
+func parseSecureMessageEnvelope(data []byte) (*secureMessageEnvelope, error) {
+	headerSize := len(secureMessageMagic) + 2
+	if len(data) < headerSize+secureMessageSaltSize {
+		return nil, ErrInvalidSecureMessage
+	}
+
+	if !bytes.Equal(data[:len(secureMessageMagic)], secureMessageMagic) ||
+		SecureMessageVersion(data[len(secureMessageMagic)]) != SecureMessageV2 {
+		return nil, ErrInvalidSecureMessage
+	}
+
+	count := int(data[len(secureMessageMagic)+1])
+	if count == 0 {
+		return nil, ErrInvalidSecureMessage
+	}
+
+	offset := headerSize
+	if len(data) < offset+secureMessageSaltSize+count*secureMessageRecipientSize+secureMessageNonceSize+secureMessageTagSize {
+		return nil, ErrInvalidSecureMessage
+	}
+
+	e := &secureMessageEnvelope{
+		Salt:       data[offset : offset+secureMessageSaltSize],
+		Recipients: make([]*secureMessageRecipient, count),
+	}
+	offset += secureMessageSaltSize
+
+	for i := range e.Recipients {
+		e.Recipients[i] = &secureMessageRecipient{
+			PublicKey:  data[offset : offset+secureMessagePublicKeySize],
+			Nonce:      data[offset+secureMessagePublicKeySize : offset+secureMessagePublicKeySize+secureMessageNonceSize],
+			WrappedKey: data[offset+secureMessagePublicKeySize+secureMessageNonceSize : offset+secureMessageRecipientSize],
+		}
+		offset += secureMessageRecipientSize
+	}
+
+	e.Nonce = data[offset : offset+secureMessageNonceSize]
+	e.Ciphertext = data[offset+secureMessageNonceSize:]
+
+	return e, nil
+}
+
+// Version returns the version of secure message envelope
+func (m *SecureMessage) Version() SecureMessageVersion {
+	if _, err := parseSecureMessageEnvelope(m.encodedData); err == nil {
+		return SecureMessageV2
+	}
+
+	return SecureMessageV1
+}
+
+// Recipients returns public keys of accounts which are able to decrypt SecureMessageV2.
+// Legacy messages do not store the recipient, so nil is returned for them
+func (m *SecureMessage) Recipients() []string {
+	e, err := parseSecureMessageEnvelope(m.encodedData)
+	if err != nil {
+		return nil
+	}
+
+	keys := make([]string, len(e.Recipients))
+	for i, r := range e.Recipients {
+		keys[i] = xpxcrypto.NewPublicKey(r.PublicKey).String()
+	}
+
+	return keys
+}
+
+// NewSecureMessageV2FromPlainText encrypts plain text with AES-GCM for every passed recipient.
+// Content is encrypted once with a random key, which is wrapped for each recipient
+// with a key derived by HKDF from the sender and recipient shared secret
+func NewSecureMessageV2FromPlainText(plainText string, sender *xpxcrypto.PrivateKey, recipients ...*xpxcrypto.PublicKey) (*SecureMessage, error) {
+	if len(recipients) == 0 {
+		return nil, ErrNoMessageRecipients
+	}
+
+	if len(recipients) > secureMessageMaxRecipients {
+		return nil, ErrTooManyMessageRecipients
+	}
+
+	salt, err := randomBytes(secureMessageSaltSize)
+	if err != nil {
+		return nil, err
+	}
+
+	contentKey, err := randomBytes(secureMessageContentKeySize)
+	if err != nil {
+		return nil, err
+	}
+
+	unique := make([]*xpxcrypto.PublicKey, 0, len(recipients))
+	seen := make(map[string]bool, len(recipients))
+	for _, recipient := range recipients {
+		if recipient == nil {
+			return nil, ErrNilAccount
+		}
+
+		if seen[recipient.String()] {
+			continue
+		}
+		seen[recipient.String()] = true
+
+		unique = append(unique, recipient)
+	}
+
+	e := &secureMessageEnvelope{
+		Salt:       salt,
+		Recipients: make([]*secureMessageRecipient, 0, len(unique)),
+	}
+
+	for _, recipient := range unique {
+		wrappingKey, err := deriveSecureMessageKey(sender, recipient, salt)
+		if err != nil {
+			return nil, err
+		}
+
+		nonce, wrapped, err := sealAesGcm(wrappingKey, contentKey, e.wrapAdditionalData(len(unique), recipient.Raw))
+		if err != nil {
+			return nil, err
+		}
+
+		e.Recipients = append(e.Recipients, &secureMessageRecipient{
+			PublicKey:  recipient.Raw,
+			Nonce:      nonce,
+			WrappedKey: wrapped,
+		})
+	}
+
+	e.Nonce, e.Ciphertext, err = sealAesGcm(contentKey, []byte(plainText), e.header())
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSecureMessage(e.bytes()), nil
+}
+
+// decryptSecureMessageV2 returns plain text of SecureMessageV2 for passed recipient and sender keys
+func decryptSecureMessageV2(e *secureMessageEnvelope, recipient *xpxcrypto.PrivateKey, sender *xpxcrypto.PublicKey) ([]byte, error) {
+	rkp, err := xpxcrypto.NewKeyPair(recipient, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	r := e.recipient(rkp.PublicKey.Raw)
+	if r == nil {
+		return nil, ErrNotMessageRecipient
+	}
+
+	wrappingKey, err := deriveSecureMessageKey(recipient, sender, e.Salt)
+	if err != nil {
+		return nil, err
+	}
+
+	contentKey, err := openAesGcm(wrappingKey, r.Nonce, r.WrappedKey, e.wrapAdditionalData(len(e.Recipients), r.PublicKey))
+	if err != nil {
+		return nil, err
+	}
+
+	return openAesGcm(contentKey, e.Nonce, e.Ciphertext, e.header())
+}
+
+// deriveSecureMessageKey returns the key for wrapping of content key.
+// The shared secret is symmetric, so sender and recipient derive the same key
+func deriveSecureMessageKey(privateKey *xpxcrypto.PrivateKey, publicKey *xpxcrypto.PublicKey, salt []byte) ([]byte, error) {
+	kp, err := xpxcrypto.NewKeyPair(privateKey, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	pkp, err := xpxcrypto.NewKeyPair(nil, publicKey, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Zero salt keeps the plain ECDH shared secret, salting is done by HKDF
+	sharedSecret, err := xpxcrypto.NewEd25519BlockCipher(kp, pkp, nil).GetSharedKey(privateKey, publicKey, make([]byte, secureMessagePublicKeySize))
+	if err != nil {
+		return nil, err
+	}
+
+	key := make([]byte, secureMessageContentKeySize)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, sharedSecret, salt, secureMessageHkdfInfo), key); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+func sealAesGcm(key, plainText, additionalData []byte) (nonce []byte, cipherText []byte, err error) {
+	gcm, err := newAesGcm(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	nonce, err = randomBytes(gcm.NonceSize())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return nonce, gcm.Seal(nil, nonce, plainText, additionalData), nil
+}
+
+func openAesGcm(key, nonce, cipherText, additionalData []byte) ([]byte, error) {
+	gcm, err := newAesGcm(key)
+	if err != nil {
+		return nil, err
+	}
+
+	plainText, err := gcm.Open(nil, nonce, cipherText, additionalData)
+	if err != nil {
+		return nil, ErrInvalidSecureMessage
+	}
+
+	return plainText, nil
+}
+
+func newAesGcm(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+func randomBytes(size int) ([]byte, error) {
+	b := make([]byte, size)
+	if _, err := io.ReadFull(rand.Reader, b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
@@ -0,0 +1,126 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/proximax-storage/go-xpx-crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+const testSecureMessage = "Hello guys, let's do this!"
+
+func newTestKeyPair(t *testing.T) *crypto.KeyPair {
+	kp, err := crypto.NewKeyPairByEngine(crypto.CryptoEngines.DefaultEngine)
+	assert.Nil(t, err)
+	return kp
+}
+
+func TestSecureMessageV2_SingleRecipient(t *testing.T) {
+	sender, recipient := newTestKeyPair(t), newTestKeyPair(t)
+
+	secureMessage, err := NewSecureMessageV2FromPlainText(testSecureMessage, sender.PrivateKey, recipient.PublicKey)
+	assert.Nil(t, err)
+	assert.Equal(t, SecureMessageV2, secureMessage.Version())
+	assert.Equal(t, []string{recipient.PublicKey.String()}, secureMessage.Recipients())
+
+	plainMessage, err := NewPlainMessageFromEncodedData(secureMessage.Payload(), recipient.PrivateKey, sender.PublicKey)
+	assert.Nil(t, err)
+	assert.Equal(t, testSecureMessage, plainMessage.Message())
+}
+
+func TestSecureMessageV2_MultipleRecipients(t *testing.T) {
+	sender := newTestKeyPair(t)
+	recipients := []*crypto.KeyPair{newTestKeyPair(t), newTestKeyPair(t), newTestKeyPair(t)}
+
+	secureMessage, err := NewSecureMessageV2FromPlainText(
+		testSecureMessage,
+		sender.PrivateKey,
+		recipients[0].PublicKey, recipients[1].PublicKey, recipients[2].PublicKey, recipients[0].PublicKey,
+	)
+	assert.Nil(t, err)
+	assert.Len(t, secureMessage.Recipients(), 3)
+
+	for _, recipient := range recipients {
+		plainMessage, err := NewPlainMessageFromEncodedData(secureMessage.Payload(), recipient.PrivateKey, sender.PublicKey)
+		assert.Nil(t, err)
+		assert.Equal(t, testSecureMessage, plainMessage.Message())
+	}
+
+	_, err = NewPlainMessageFromEncodedData(secureMessage.Payload(), newTestKeyPair(t).PrivateKey, sender.PublicKey)
+	assert.Equal(t, ErrNotMessageRecipient, err)
+}
+
+func TestSecureMessageV2_Tampered(t *testing.T) {
+	sender, recipient := newTestKeyPair(t), newTestKeyPair(t)
+
+	secureMessage, err := NewSecureMessageV2FromPlainText(testSecureMessage, sender.PrivateKey, recipient.PublicKey)
+	assert.Nil(t, err)
+
+	payload := append([]byte{}, secureMessage.Payload()...)
+	payload[len(payload)-1] ^= 0xff
+
+	_, err = NewPlainMessageFromEncodedData(payload, recipient.PrivateKey, sender.PublicKey)
+	assert.Equal(t, ErrInvalidSecureMessage, err)
+}
+
+func TestSecureMessageV2_TamperedHeader(t *testing.T) {
+	sender, recipient, other := newTestKeyPair(t), newTestKeyPair(t), newTestKeyPair(t)
+
+	secureMessage, err := NewSecureMessageV2FromPlainText(testSecureMessage, sender.PrivateKey, recipient.PublicKey, other.PublicKey)
+	assert.Nil(t, err)
+
+	e, err := parseSecureMessageEnvelope(secureMessage.Payload())
+	assert.Nil(t, err)
+
+	// wrapped key of another recipient is a part of authenticated header
+	payload := append([]byte{}, secureMessage.Payload()...)
+	payload[len(e.preamble(2))+2*secureMessageRecipientSize-1] ^= 0xff
+
+	_, err = NewPlainMessageFromEncodedData(payload, recipient.PrivateKey, sender.PublicKey)
+	assert.Equal(t, ErrInvalidSecureMessage, err)
+
+	// recipient count is authenticated with wrapped key
+	payload = append([]byte{}, secureMessage.Payload()...)
+	payload[len(secureMessageMagic)+1] = 1
+
+	_, err = NewPlainMessageFromEncodedData(payload, recipient.PrivateKey, sender.PublicKey)
+	assert.Equal(t, ErrInvalidSecureMessage, err)
+}
+
+func TestSecureMessageV1_BackwardsCompatible(t *testing.T) {
+	sender, err := NewAccount(PublicTest, &Hash{})
+	assert.Nil(t, err)
+	recipient, err := NewAccount(PublicTest, &Hash{})
+	assert.Nil(t, err)
+
+	secureMessage, err := sender.EncryptMessage(testSecureMessage, recipient.PublicAccount)
+	assert.Nil(t, err)
+	assert.Equal(t, SecureMessageV1, secureMessage.Version())
+	assert.Nil(t, secureMessage.Recipients())
+
+	plainMessage, err := recipient.DecryptMessage(secureMessage, sender.PublicAccount)
+	assert.Nil(t, err)
+	assert.Equal(t, testSecureMessage, plainMessage.Message())
+}
+
+func TestAccount_EncryptMessageForCosignatories(t *testing.T) {
+	sender, err := NewAccount(PublicTest, &Hash{})
+	assert.Nil(t, err)
+	cosignatory1, err := NewAccount(PublicTest, &Hash{})
+	assert.Nil(t, err)
+	cosignatory2, err := NewAccount(PublicTest, &Hash{})
+	assert.Nil(t, err)
+
+	multisigInfo := &MultisigAccountInfo{
+		Cosignatories: []*PublicAccount{cosignatory1.PublicAccount, cosignatory2.PublicAccount},
+	}
+
+	secureMessage, err := sender.EncryptMessageForCosignatories(testSecureMessage, multisigInfo)
+	assert.Nil(t, err)
+
+	for _, cosignatory := range []*Account{cosignatory1, cosignatory2} {
+		plainMessage, err := cosignatory.DecryptMessage(secureMessage, sender.PublicAccount)
+		assert.Nil(t, err)
+		assert.Equal(t, testSecureMessage, plainMessage.Message())
+	}
+}
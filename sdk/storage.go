@@ -59,6 +59,31 @@ func (s *StorageService) GetDrives(ctx context.Context, dpOpts *DrivesPageOption
 	return dspDTO.toStruct(s.client.NetworkType())
 }
 
+// IterateDrives returns iterator over drives of every page matching options, call Close when iteration is stopped early
+func (s *StorageService) IterateDrives(ctx context.Context, dpOpts *DrivesPageOptions, itOpts *IteratorOptions) *DriveIterator {
+	opts := DrivesPageOptions{}
+	if dpOpts != nil {
+		opts = *dpOpts
+	}
+
+	return &DriveIterator{it: newPageIterator(ctx, func(ctx context.Context, pageNumber uint64) ([]interface{}, Pagination, error) {
+		pageOpts := opts
+		pageOpts.PageNumber = pageNumber
+
+		drivesPage, err := s.GetDrives(ctx, &pageOpts)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+
+		items := make([]interface{}, len(drivesPage.Drives))
+		for i, d := range drivesPage.Drives {
+			items[i] = d
+		}
+
+		return items, drivesPage.Pagination, nil
+	}, itOpts)}
+}
+
 type DriveParticipantFilter string
 
 const (
@@ -0,0 +1,323 @@
+// Copyright 2020 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"strings"
+)
+
+const abiWordSize = 8
+
+// EncodeAbiParams packs arguments into int64 words by types of parameters.
+// Tuples accept map by component names or slice of components in their order
+func EncodeAbiParams(params []*AbiParam, args []interface{}) ([]int64, error) {
+	if len(params) != len(args) {
+		return nil, ErrAbiArgumentsCount
+	}
+
+	words := make([]int64, 0, len(params))
+	for i, p := range params {
+		encoded, err := encodeAbiParam(p, args[i])
+		if err != nil {
+			return nil, err
+		}
+
+		words = append(words, encoded...)
+	}
+
+	return words, nil
+}
+
+// DecodeAbiParams unpacks words by types of parameters. Integers are decoded as int64 and uint64,
+// addresses as *Address, hashes as *Hash, public keys as hex strings and tuples as maps by component names
+func DecodeAbiParams(params []*AbiParam, words []int64) ([]interface{}, error) {
+	values, rest, err := decodeAbiParams(params, words)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rest) != 0 {
+		return nil, ErrAbiInvalidData
+	}
+
+	return values, nil
+}
+
+func encodeAbiParam(p *AbiParam, arg interface{}) ([]int64, error) {
+	switch p.Type {
+	case AbiInt64:
+		v, ok := abiInt64(arg)
+		if !ok {
+			return nil, ErrAbiInvalidValue
+		}
+
+		return []int64{v}, nil
+	case AbiUint64:
+		v, ok := abiUint64(arg)
+		if !ok {
+			return nil, ErrAbiInvalidValue
+		}
+
+		return []int64{int64(v)}, nil
+	case AbiBool:
+		v, ok := arg.(bool)
+		if !ok {
+			return nil, ErrAbiInvalidValue
+		}
+
+		if v {
+			return []int64{1}, nil
+		}
+
+		return []int64{0}, nil
+	case AbiString:
+		v, ok := arg.(string)
+		if !ok {
+			return nil, ErrAbiInvalidValue
+		}
+
+		return append([]int64{int64(len(v))}, packAbiBytes([]byte(v))...), nil
+	case AbiBytes:
+		v, ok := arg.([]byte)
+		if !ok {
+			return nil, ErrAbiInvalidValue
+		}
+
+		return append([]int64{int64(len(v))}, packAbiBytes(v)...), nil
+	case AbiAddress:
+		v, ok := arg.(*Address)
+		if !ok || v == nil {
+			return nil, ErrAbiInvalidValue
+		}
+
+		raw, err := v.Decode()
+		if err != nil || len(raw) != AddressSize {
+			return nil, ErrAbiInvalidValue
+		}
+
+		return packAbiBytes(raw), nil
+	case AbiHash:
+		v, ok := arg.(*Hash)
+		if !ok || v == nil {
+			return nil, ErrAbiInvalidValue
+		}
+
+		return packAbiBytes(v[:]), nil
+	case AbiPublicKey:
+		var key string
+		switch v := arg.(type) {
+		case string:
+			key = v
+		case *PublicAccount:
+			if v == nil {
+				return nil, ErrAbiInvalidValue
+			}
+			key = v.PublicKey
+		default:
+			return nil, ErrAbiInvalidValue
+		}
+
+		raw, err := hex.DecodeString(key)
+		if err != nil || len(raw) != KeySize {
+			return nil, ErrAbiInvalidValue
+		}
+
+		return packAbiBytes(raw), nil
+	case AbiTuple:
+		switch v := arg.(type) {
+		case []interface{}:
+			return EncodeAbiParams(p.Components, v)
+		case map[string]interface{}:
+			if len(v) != len(p.Components) {
+				return nil, ErrAbiArgumentsCount
+			}
+
+			args := make([]interface{}, len(p.Components))
+			for i, c := range p.Components {
+				value, ok := v[c.Name]
+				if !ok {
+					return nil, ErrAbiArgumentsCount
+				}
+
+				args[i] = value
+			}
+
+			return EncodeAbiParams(p.Components, args)
+		default:
+			return nil, ErrAbiInvalidValue
+		}
+	default:
+		return nil, ErrAbiInvalidType
+	}
+}
+
+func decodeAbiParams(params []*AbiParam, words []int64) ([]interface{}, []int64, error) {
+	values := make([]interface{}, len(params))
+	for i, p := range params {
+		var err error
+		values[i], words, err = decodeAbiParam(p, words)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return values, words, nil
+}
+
+func decodeAbiParam(p *AbiParam, words []int64) (interface{}, []int64, error) {
+	switch p.Type {
+	case AbiInt64, AbiUint64, AbiBool:
+		if len(words) == 0 {
+			return nil, nil, ErrAbiInvalidData
+		}
+
+		switch p.Type {
+		case AbiUint64:
+			return uint64(words[0]), words[1:], nil
+		case AbiBool:
+			if words[0] != 0 && words[0] != 1 {
+				return nil, nil, ErrAbiInvalidData
+			}
+
+			return words[0] == 1, words[1:], nil
+		default:
+			return words[0], words[1:], nil
+		}
+	case AbiString, AbiBytes:
+		// length is checked before conversion to int, so it can't overflow
+		if len(words) == 0 || words[0] < 0 || words[0] > int64(len(words)-1)*abiWordSize {
+			return nil, nil, ErrAbiInvalidData
+		}
+
+		raw, rest, err := unpackAbiBytes(words[1:], int(words[0]))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if p.Type == AbiString {
+			return string(raw), rest, nil
+		}
+
+		return raw, rest, nil
+	case AbiAddress:
+		raw, rest, err := unpackAbiBytes(words, AddressSize)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		address, err := NewAddressFromRaw(base32.StdEncoding.EncodeToString(raw))
+		if err != nil {
+			return nil, nil, ErrAbiInvalidData
+		}
+
+		return address, rest, nil
+	case AbiHash:
+		raw, rest, err := unpackAbiBytes(words, Hash256)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		hash, err := bytesToHash(raw)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return hash, rest, nil
+	case AbiPublicKey:
+		raw, rest, err := unpackAbiBytes(words, KeySize)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return strings.ToUpper(hex.EncodeToString(raw)), rest, nil
+	case AbiTuple:
+		values, rest, err := decodeAbiParams(p.Components, words)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		tuple := make(map[string]interface{}, len(values))
+		for i, c := range p.Components {
+			tuple[c.Name] = values[i]
+		}
+
+		return tuple, rest, nil
+	default:
+		return nil, nil, ErrAbiInvalidType
+	}
+}
+
+// packAbiBytes packs bytes by 8 into big endian words, the last word is padded with zeros
+func packAbiBytes(b []byte) []int64 {
+	words := make([]int64, (len(b)+abiWordSize-1)/abiWordSize)
+	chunk := make([]byte, abiWordSize)
+
+	for i := range words {
+		for j := range chunk {
+			chunk[j] = 0
+		}
+
+		copy(chunk, b[i*abiWordSize:])
+		words[i] = int64(binary.BigEndian.Uint64(chunk))
+	}
+
+	return words
+}
+
+// unpackAbiBytes unpacks size bytes from words, size is validated before allocation of bytes
+func unpackAbiBytes(words []int64, size int) ([]byte, []int64, error) {
+	if size < 0 || size > len(words)*abiWordSize {
+		return nil, nil, ErrAbiInvalidData
+	}
+
+	count := (size + abiWordSize - 1) / abiWordSize
+
+	b := make([]byte, count*abiWordSize)
+	for i := 0; i < count; i++ {
+		binary.BigEndian.PutUint64(b[i*abiWordSize:], uint64(words[i]))
+	}
+
+	return b[:size], words[count:], nil
+}
+
+func abiInt64(arg interface{}) (int64, bool) {
+	switch v := arg.(type) {
+	case int64:
+		return v, true
+	case int:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case int16:
+		return int64(v), true
+	case int8:
+		return int64(v), true
+	default:
+		return 0, false
+	}
+}
+
+func abiUint64(arg interface{}) (uint64, bool) {
+	switch v := arg.(type) {
+	case uint64:
+		return v, true
+	case uint:
+		return uint64(v), true
+	case uint32:
+		return uint64(v), true
+	case uint16:
+		return uint64(v), true
+	case uint8:
+		return uint64(v), true
+	case Amount:
+		// Height, Duration and StorageSize are the same type as Amount
+		return uint64(v), true
+	default:
+		return 0, false
+	}
+}
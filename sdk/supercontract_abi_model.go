@@ -0,0 +1,155 @@
+// Copyright 2020 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"io/ioutil"
+)
+
+// AbiType is a type of supercontract function parameter
+type AbiType string
+
+// Parameters are packed into vector of int64 words. Integers and booleans take one word,
+// strings and bytes take word with their length followed by their content packed by 8 bytes in big endian order,
+// addresses, hashes and public keys take 4 words, tuples are sequences of their components
+const (
+	AbiInt64     AbiType = "int64"
+	AbiUint64    AbiType = "uint64"
+	AbiBool      AbiType = "bool"
+	AbiString    AbiType = "string"
+	AbiBytes     AbiType = "bytes"
+	AbiAddress   AbiType = "address"
+	AbiHash      AbiType = "hash"
+	AbiPublicKey AbiType = "publicKey"
+	AbiTuple     AbiType = "tuple"
+)
+
+// AbiParam describes parameter of supercontract function
+type AbiParam struct {
+	Name string  `json:"name"`
+	Type AbiType `json:"type"`
+	// Components are fields of tuple
+	Components []*AbiParam `json:"components,omitempty"`
+}
+
+func (p *AbiParam) validate() error {
+	switch p.Type {
+	case AbiInt64, AbiUint64, AbiBool, AbiString, AbiBytes, AbiAddress, AbiHash, AbiPublicKey:
+		return nil
+	case AbiTuple:
+		if len(p.Components) == 0 {
+			return ErrAbiInvalidType
+		}
+
+		return validateAbiParams(p.Components)
+	default:
+		return ErrAbiInvalidType
+	}
+}
+
+func validateAbiParams(params []*AbiParam) error {
+	for _, p := range params {
+		if p == nil {
+			return ErrAbiInvalidType
+		}
+
+		if err := p.validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AbiFunction describes function of supercontract
+type AbiFunction struct {
+	Name    string      `json:"name"`
+	Inputs  []*AbiParam `json:"inputs"`
+	Outputs []*AbiParam `json:"outputs,omitempty"`
+}
+
+// EncodeInputs packs arguments into parameters of StartExecuteTransaction
+func (f *AbiFunction) EncodeInputs(args ...interface{}) ([]int64, error) {
+	return EncodeAbiParams(f.Inputs, args)
+}
+
+// DecodeInputs unpacks parameters of StartExecuteTransaction
+func (f *AbiFunction) DecodeInputs(words []int64) ([]interface{}, error) {
+	return DecodeAbiParams(f.Inputs, words)
+}
+
+// DecodeOutputs unpacks results of function
+func (f *AbiFunction) DecodeOutputs(words []int64) ([]interface{}, error) {
+	return DecodeAbiParams(f.Outputs, words)
+}
+
+// AbiSchema describes functions of supercontract, it's shipped as JSON file alongside of wasm file:
+//
+//	{
+//		"contract": "token",
+//		"functions": [
+//			{"name": "transfer", "inputs": [{"name": "to", "type": "address"}, {"name": "amount", "type": "uint64"}]}
+//		]
+//	}
+type AbiSchema struct {
+	Contract  string         `json:"contract"`
+	Functions []*AbiFunction `json:"functions"`
+}
+
+// ParseAbiSchema parses JSON schema and checks types of parameters
+func ParseAbiSchema(data []byte) (*AbiSchema, error) {
+	schema := &AbiSchema{}
+	if err := json.Unmarshal(data, schema); err != nil {
+		return nil, err
+	}
+
+	for _, f := range schema.Functions {
+		if err := validateAbiParams(f.Inputs); err != nil {
+			return nil, err
+		}
+
+		if err := validateAbiParams(f.Outputs); err != nil {
+			return nil, err
+		}
+	}
+
+	return schema, nil
+}
+
+// LoadAbiSchema reads JSON schema from file
+func LoadAbiSchema(path string) (*AbiSchema, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseAbiSchema(data)
+}
+
+// Function returns function by its name
+func (s *AbiSchema) Function(name string) (*AbiFunction, error) {
+	for _, f := range s.Functions {
+		if f.Name == name {
+			return f, nil
+		}
+	}
+
+	return nil, ErrAbiFunctionNotFound
+}
+
+// DecodeStartExecute returns function called by transaction and its decoded arguments
+func (s *AbiSchema) DecodeStartExecute(tx *StartExecuteTransaction) (*AbiFunction, []interface{}, error) {
+	f, err := s.Function(tx.Function)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	args, err := f.DecodeInputs(tx.FunctionParameters)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return f, args, nil
+}
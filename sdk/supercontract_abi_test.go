@@ -0,0 +1,121 @@
+package sdk
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testAbiSchemaJson = `{
+	"contract": "token",
+	"functions": [
+		{
+			"name": "transfer",
+			"inputs": [
+				{"name": "to", "type": "address"},
+				{"name": "amount", "type": "uint64"},
+				{"name": "memo", "type": "string"},
+				{"name": "order", "type": "tuple", "components": [
+					{"name": "id", "type": "int64"},
+					{"name": "paid", "type": "bool"},
+					{"name": "payload", "type": "bytes"}
+				]}
+			],
+			"outputs": [
+				{"name": "receipt", "type": "hash"},
+				{"name": "owner", "type": "publicKey"}
+			]
+		}
+	]
+}`
+
+func TestParseAbiSchema(t *testing.T) {
+	schema, err := ParseAbiSchema([]byte(testAbiSchemaJson))
+	assert.Nil(t, err)
+	assert.Equal(t, "token", schema.Contract)
+
+	f, err := schema.Function("transfer")
+	assert.Nil(t, err)
+	assert.Len(t, f.Inputs, 4)
+	assert.Len(t, f.Inputs[3].Components, 3)
+
+	_, err = schema.Function("mint")
+	assert.Equal(t, ErrAbiFunctionNotFound, err)
+
+	_, err = ParseAbiSchema([]byte(`{"functions": [{"name": "f", "inputs": [{"name": "a", "type": "float"}]}]}`))
+	assert.Equal(t, ErrAbiInvalidType, err)
+
+	_, err = ParseAbiSchema([]byte(`{"functions": [{"name": "f", "inputs": [{"name": "a", "type": "tuple"}]}]}`))
+	assert.Equal(t, ErrAbiInvalidType, err)
+}
+
+func TestAbiFunction_Encode(t *testing.T) {
+	schema, err := ParseAbiSchema([]byte(testAbiSchemaJson))
+	assert.Nil(t, err)
+
+	f, err := schema.Function("transfer")
+	assert.Nil(t, err)
+
+	words, err := f.EncodeInputs(
+		testReplicatorAccount.Address,
+		uint64(1<<63),
+		"hello, world",
+		map[string]interface{}{"id": -5, "paid": true, "payload": []byte{1, 2, 3}},
+	)
+	assert.Nil(t, err)
+	// address of 4 words, amount, length and 2 words of memo, id, paid, length and 1 word of payload
+	assert.Len(t, words, 12)
+	assert.Equal(t, int64(-1<<63), words[4])
+	assert.Equal(t, int64(12), words[5])
+	assert.Equal(t, int64(0x0102030000000000), words[11])
+
+	args, err := f.DecodeInputs(words)
+	assert.Nil(t, err)
+	assert.Equal(t, testReplicatorAccount.Address.Address, args[0].(*Address).Address)
+	assert.Equal(t, uint64(1<<63), args[1])
+	assert.Equal(t, "hello, world", args[2])
+	assert.Equal(t, map[string]interface{}{"id": int64(-5), "paid": true, "payload": []byte{1, 2, 3}}, args[3])
+
+	// tuple can be passed as slice of components
+	positional, err := f.EncodeInputs(testReplicatorAccount.Address, uint64(1<<63), "hello, world", []interface{}{-5, true, []byte{1, 2, 3}})
+	assert.Nil(t, err)
+	assert.Equal(t, words, positional)
+
+	_, err = f.EncodeInputs(testReplicatorAccount.Address)
+	assert.Equal(t, ErrAbiArgumentsCount, err)
+
+	_, err = f.EncodeInputs(testReplicatorAccount.Address, -1, "", []interface{}{1, true, []byte{}})
+	assert.Equal(t, ErrAbiInvalidValue, err)
+
+	_, err = f.DecodeInputs(words[:11])
+	assert.Equal(t, ErrAbiInvalidData, err)
+
+	_, err = f.DecodeInputs(append(words, 0))
+	assert.Equal(t, ErrAbiInvalidData, err)
+
+	// length of bytes exceeding parameter vector is rejected before allocation
+	for _, length := range []int64{math.MaxInt64, -1, 17} {
+		_, err = DecodeAbiParams([]*AbiParam{{Name: "data", Type: AbiBytes}}, []int64{length, 1, 2})
+		assert.Equal(t, ErrAbiInvalidData, err)
+	}
+
+	_, err = DecodeAbiParams([]*AbiParam{{Name: "name", Type: AbiString}}, []int64{math.MaxInt64})
+	assert.Equal(t, ErrAbiInvalidData, err)
+
+	outputs, err := EncodeAbiParams(f.Outputs, []interface{}{&Hash{1, 2}, testDriveOwnerAccount})
+	assert.Nil(t, err)
+
+	results, err := f.DecodeOutputs(outputs)
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{&Hash{1, 2}, testDriveOwnerAccount.PublicKey}, results)
+
+	tx, err := NewStartExecuteTransaction(NewDeadline(time.Hour), testDriveAccount, []*Mosaic{Xpx(1)}, f.Name, words, PublicTest)
+	assert.Nil(t, err)
+
+	called, decoded, err := schema.DecodeStartExecute(tx)
+	assert.Nil(t, err)
+	assert.Equal(t, f, called)
+	assert.Equal(t, args, decoded)
+}
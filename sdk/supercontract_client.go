@@ -0,0 +1,138 @@
+// Copyright 2020 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"context"
+)
+
+// SuperContractClient executes functions of supercontracts and tracks their operations
+type SuperContractClient struct {
+	client *Client
+	config *SuperContractClientConfig
+}
+
+func NewSuperContractClient(client *Client, config *SuperContractClientConfig) (*SuperContractClient, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	return &SuperContractClient{
+		client: client,
+		config: config,
+	}, nil
+}
+
+// Execute announces StartExecuteTransaction and waits until its operation is finished.
+// Operation token is a hash of StartExecuteTransaction, spent mosaics are taken from EndExecuteTransaction of operation
+func (c *SuperContractClient) Execute(ctx context.Context, contract *PublicAccount, function string, params []int64, mosaics []*Mosaic) (*ExecutionResult, error) {
+	tx, err := c.client.NewStartExecuteTransaction(NewDeadline(c.config.Deadline), contract, mosaics, function, params)
+	if err != nil {
+		return nil, err
+	}
+
+	signed, err := c.config.Initiator.Sign(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.client.Transaction.announceAndWait(ctx, signed, c.config.PollInterval); err != nil {
+		return nil, err
+	}
+
+	return c.WaitOperation(ctx, signed.Hash)
+}
+
+// ExecuteFunction encodes arguments by ABI of function and executes it
+func (c *SuperContractClient) ExecuteFunction(ctx context.Context, contract *PublicAccount, function *AbiFunction, mosaics []*Mosaic, args ...interface{}) (*ExecutionResult, error) {
+	params, err := function.EncodeInputs(args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Execute(ctx, contract, function.Name, params, mosaics)
+}
+
+// WaitOperation waits until operation is finished or removed from node and returns its result.
+// ErrOperationNotFound is returned when operation isn't seen during NotFoundPolls polls
+func (c *SuperContractClient) WaitOperation(ctx context.Context, token *Hash) (*ExecutionResult, error) {
+	var operation *Operation
+	notFound := 0
+
+	err := poll(ctx, c.config.PollInterval, func() (bool, error) {
+		op, err := c.client.SuperContract.GetOperation(ctx, token)
+		if isNotFoundError(err) {
+			// operation is removed from node after it was seen
+			if operation != nil {
+				return true, nil
+			}
+
+			notFound++
+			if notFound >= c.config.NotFoundPolls {
+				return false, ErrOperationNotFound
+			}
+
+			return false, nil
+		}
+
+		if err != nil {
+			return false, err
+		}
+
+		operation = op
+
+		return isOperationFinished(op.Status), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ExecutionResult{
+		OperationToken: token,
+		Status:         operation.Status,
+		Executors:      operation.Executors,
+		SpentMosaics:   make([]*Mosaic, 0),
+		Operation:      operation,
+	}
+
+	endTx, err := c.findEndOperation(ctx, token, operation)
+	if err != nil {
+		return nil, err
+	}
+
+	if endTx != nil {
+		result.Status = endTx.Status
+		result.SpentMosaics = endTx.UsedMosaics
+	}
+
+	return result, nil
+}
+
+// findEndOperation looks for EndExecuteTransaction among aggregate transactions sent during operation
+func (c *SuperContractClient) findEndOperation(ctx context.Context, token *Hash, operation *Operation) (*EndOperationTransaction, error) {
+	for _, hash := range operation.AggregateHashes {
+		tx, err := c.client.Transaction.GetTransaction(ctx, Confirmed, hash.String())
+		if isNotFoundError(err) {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		inner := []Transaction{tx}
+		if aggTx, ok := tx.(*AggregateTransaction); ok {
+			inner = aggTx.InnerTransactions
+		}
+
+		for _, innerTx := range inner {
+			if endTx, ok := innerTx.(*EndOperationTransaction); ok && endTx.OperationToken != nil && *endTx.OperationToken == *token {
+				return endTx, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,68 @@
+// Copyright 2020 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"time"
+
+	"github.com/proximax-storage/go-xpx-utils/str"
+)
+
+const defaultOperationNotFoundPolls = 30
+
+// SuperContractClientConfig is a configuration of SuperContractClient
+type SuperContractClientConfig struct {
+	// Initiator signs StartExecuteTransaction and locks mosaics for execution
+	Initiator    *Account
+	PollInterval time.Duration
+	Deadline     time.Duration
+	// NotFoundPolls is a count of polls after which waiting of operation which was never seen is given up
+	NotFoundPolls int
+}
+
+func (c *SuperContractClientConfig) validate() error {
+	if c == nil || c.Initiator == nil {
+		return ErrInvalidSuperContractConfig
+	}
+
+	if c.PollInterval <= 0 {
+		c.PollInterval = time.Second
+	}
+
+	if c.Deadline <= 0 {
+		c.Deadline = time.Hour
+	}
+
+	if c.NotFoundPolls <= 0 {
+		c.NotFoundPolls = defaultOperationNotFoundPolls
+	}
+
+	return nil
+}
+
+// ExecutionResult is a result of finished execution of supercontract function
+type ExecutionResult struct {
+	OperationToken *Hash
+	Status         OperationStatus
+	Executors      []*PublicAccount
+	// SpentMosaics are mosaics used by executors, they are reported by EndExecuteTransaction
+	SpentMosaics []*Mosaic
+	// Operation is the last known state of operation
+	Operation *Operation
+}
+
+func (r *ExecutionResult) String() string {
+	return str.StructToString(
+		"ExecutionResult",
+		str.NewField("OperationToken", str.StringPattern, r.OperationToken),
+		str.NewField("Status", str.IntPattern, r.Status),
+		str.NewField("Executors", str.StringPattern, r.Executors),
+		str.NewField("SpentMosaics", str.StringPattern, r.SpentMosaics),
+	)
+}
+
+func isOperationFinished(status OperationStatus) bool {
+	return status == Success || status == Failure
+}
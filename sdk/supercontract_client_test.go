@@ -0,0 +1,127 @@
+package sdk
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testOperationChain simulates operation on node, operation is finished after the first request
+type testOperationChain struct {
+	sync.Mutex
+	token     string
+	requested int
+}
+
+func (c *testOperationChain) register(t *testing.T, m *sdkMock, contract *PublicAccount, executor *PublicAccount) {
+	m.AddHandler(transactionsRoute, func(resp http.ResponseWriter, req *http.Request) {
+		c.Lock()
+		defer c.Unlock()
+
+		dto := &signedTransactionDto{}
+		assert.Nil(t, json.NewDecoder(req.Body).Decode(dto))
+		c.token = dto.Hash
+
+		resp.WriteHeader(http.StatusAccepted)
+		fmt.Fprint(resp, `{"message": "packet 9 was pushed to the network via /transaction"}`)
+	})
+	m.AddHandler(strings.TrimSuffix(transactionStatusByIdRoute, "%s"), func(resp http.ResponseWriter, req *http.Request) {
+		hash := req.URL.Path[strings.LastIndex(req.URL.Path, "/")+1:]
+		fmt.Fprintf(resp, `{"group": "confirmed", "status": "Success", "hash": "%s", "deadline": [1, 0], "height": [1, 0]}`, hash)
+	})
+	m.AddHandler(strings.TrimSuffix(operationRoute, "%s"), func(resp http.ResponseWriter, req *http.Request) {
+		c.Lock()
+		defer c.Unlock()
+
+		token := req.URL.Path[strings.LastIndex(req.URL.Path, "/")+1:]
+		assert.Equal(t, c.token, token)
+
+		status := Started
+		if c.requested > 0 {
+			status = Success
+		}
+		c.requested++
+
+		fmt.Fprintf(resp, `{"operation": {
+			"account": "%s", "height": [5, 0], "mosaics": [{"id": [519256100, 642862634], "amount": [100, 0]}],
+			"token": "%s", "result": %d, "executors": ["%s"], "transactionHashes": ["%064X"]
+		}}`, contract.PublicKey, token, status, executor.PublicKey, 7)
+	})
+	m.AddHandler(fmt.Sprintf(transactionsByIdRoute, Confirmed, fmt.Sprintf("%064X", 7)), func(resp http.ResponseWriter, req *http.Request) {
+		c.Lock()
+		defer c.Unlock()
+
+		fmt.Fprintf(resp, `{
+			"meta": {"height": [6, 0], "hash": "%064X", "merkleComponentHash": "%064X", "index": 0, "id": "5B686E97F0C0EA00017B9437"},
+			"transaction": {
+				"signature": "%0128X",
+				"signer": "%s",
+				"version": -1879048191,
+				"type": 17248,
+				"maxFee": [0, 0],
+				"deadline": [1094650402, 17],
+				"mosaics": [{"id": [519256100, 642862634], "amount": [40, 0]}],
+				"operationToken": "%s",
+				"result": %d
+			}
+		}`, 7, 7, 0, executor.PublicKey, c.token, Success)
+	})
+}
+
+func TestSuperContractClient_Execute(t *testing.T) {
+	mock := newSdkMock(0)
+	defer mock.Close()
+
+	initiator, err := NewAccount(PublicTest, &Hash{})
+	assert.Nil(t, err)
+
+	chain := &testOperationChain{}
+	chain.register(t, mock, testDriveAccount, testReplicatorAccount)
+
+	client := mock.getPublicTestClientUnsafe()
+
+	_, err = NewSuperContractClient(client, &SuperContractClientConfig{})
+	assert.Equal(t, ErrInvalidSuperContractConfig, err)
+
+	scClient, err := NewSuperContractClient(client, &SuperContractClientConfig{Initiator: initiator, PollInterval: time.Millisecond})
+	assert.Nil(t, err)
+
+	result, err := scClient.Execute(ctx, testDriveAccount, "run", []int64{1, 2}, []*Mosaic{Xpx(100)})
+	assert.Nil(t, err)
+	assert.Equal(t, chain.token, result.OperationToken.String())
+	assert.Equal(t, Success, result.Status)
+	assert.Equal(t, []*PublicAccount{testReplicatorAccount}, result.Executors)
+	assert.Len(t, result.SpentMosaics, 1)
+	assert.Equal(t, Amount(40), result.SpentMosaics[0].Amount)
+	assert.Equal(t, 2, chain.requested)
+}
+
+func TestSuperContractClient_WaitOperation_NotFound(t *testing.T) {
+	mock := newSdkMock(0)
+	defer mock.Close()
+
+	initiator, err := NewAccount(PublicTest, &Hash{})
+	assert.Nil(t, err)
+
+	requested := 0
+	mock.AddHandler(strings.TrimSuffix(operationRoute, "%s"), func(resp http.ResponseWriter, req *http.Request) {
+		requested++
+		resp.WriteHeader(http.StatusNotFound)
+	})
+
+	scClient, err := NewSuperContractClient(mock.getPublicTestClientUnsafe(), &SuperContractClientConfig{
+		Initiator:     initiator,
+		PollInterval:  time.Millisecond,
+		NotFoundPolls: 3,
+	})
+	assert.Nil(t, err)
+
+	_, err = scClient.WaitOperation(ctx, &Hash{1})
+	assert.Equal(t, ErrOperationNotFound, err)
+	assert.Equal(t, 3, requested)
+}
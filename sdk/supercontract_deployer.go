@@ -0,0 +1,210 @@
+// Copyright 2020 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+)
+
+// SuperContractDeployer deploys contract files stored on drive as supercontracts
+// and keeps mapping of contract versions to supercontract keys in SuperContractVersionStore
+type SuperContractDeployer struct {
+	client *Client
+	config *SuperContractDeployerConfig
+	store  SuperContractVersionStore
+}
+
+func NewSuperContractDeployer(client *Client, config *SuperContractDeployerConfig, store SuperContractVersionStore) (*SuperContractDeployer, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	if store == nil {
+		store = NewMemorySuperContractVersionStore()
+	}
+
+	return &SuperContractDeployer{
+		client: client,
+		config: config,
+		store:  store,
+	}, nil
+}
+
+// Versions returns deployed versions ordered by version number
+func (d *SuperContractDeployer) Versions() ([]*SuperContractVersion, error) {
+	return d.store.LoadSuperContractVersions(d.config.Drive.PublicKey)
+}
+
+// Latest returns the latest active version, nil is returned when drive doesn't have active versions
+func (d *SuperContractDeployer) Latest() (*SuperContractVersion, error) {
+	versions, err := d.Versions()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := len(versions) - 1; i >= 0; i-- {
+		if !versions[i].Deactivated {
+			return versions[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+// DeployFile deploys contract read from path, the same file should be uploaded to drive
+func (d *SuperContractDeployer) DeployFile(ctx context.Context, path string, vmVersion uint64) (*SuperContractVersion, error) {
+	wasm, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.Deploy(ctx, wasm, vmVersion)
+}
+
+// Deploy deploys contract with new supercontract account and records it as the next version.
+// SDK doesn't transfer content of files, so contract should be uploaded to drive by storage layer beforehand.
+// Contract is found on drive by hash computed by Hasher of config, ErrContractFileNotStored is returned
+// when drive doesn't store it, since replicators can't execute such contract
+func (d *SuperContractDeployer) Deploy(ctx context.Context, wasm []byte, vmVersion uint64) (*SuperContractVersion, error) {
+	if len(wasm) == 0 {
+		return nil, ErrEmptyContractFile
+	}
+
+	versions, err := d.Versions()
+	if err != nil {
+		return nil, err
+	}
+
+	fileHash, err := d.config.Hasher.FileHash(bytes.NewReader(wasm))
+	if err != nil {
+		return nil, err
+	}
+
+	fileSize, err := d.storedFileSize(ctx, fileHash)
+	if err != nil {
+		return nil, err
+	}
+
+	contract, err := NewAccount(d.client.NetworkType(), d.client.GenerationHash())
+	if err != nil {
+		return nil, err
+	}
+
+	deployTx, err := d.client.NewDeployTransaction(d.deadline(), d.config.Drive, d.config.Owner.PublicAccount, fileHash, vmVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	deployTx.ToAggregate(contract.PublicAccount)
+
+	aggTx, err := d.client.NewCompleteAggregateTransaction(d.deadline(), []Transaction{deployTx})
+	if err != nil {
+		return nil, err
+	}
+
+	signed, err := d.config.Owner.SignWithCosignatures(aggTx, []*Account{contract})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := d.client.Transaction.announceAndWait(ctx, signed, d.config.PollInterval); err != nil {
+		return nil, err
+	}
+
+	version := &SuperContractVersion{
+		Version:       1,
+		SuperContract: contract.PublicAccount.PublicKey,
+		FileHash:      fileHash,
+		FileSize:      fileSize,
+		VMVersion:     vmVersion,
+	}
+
+	if len(versions) > 0 {
+		version.Version = versions[len(versions)-1].Version + 1
+	}
+
+	if err := d.store.SaveSuperContractVersions(d.config.Drive.PublicKey, append(versions, version)); err != nil {
+		return nil, err
+	}
+
+	return version, nil
+}
+
+// DeactivateOlderVersions deactivates every active version older than version in one aggregate transaction,
+// so either all of them are deactivated or none
+func (d *SuperContractDeployer) DeactivateOlderVersions(ctx context.Context, version uint64) ([]*SuperContractVersion, error) {
+	versions, err := d.Versions()
+	if err != nil {
+		return nil, err
+	}
+
+	deactivated := make([]*SuperContractVersion, 0)
+	txs := make([]Transaction, 0)
+
+	for _, v := range versions {
+		if v.Version >= version || v.Deactivated {
+			continue
+		}
+
+		tx, err := d.client.NewDeactivateTransaction(d.deadline(), v.SuperContract, d.config.Drive.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+
+		tx.ToAggregate(d.config.Owner.PublicAccount)
+
+		txs = append(txs, tx)
+		deactivated = append(deactivated, v)
+	}
+
+	if len(txs) == 0 {
+		return nil, ErrNoChanges
+	}
+
+	aggTx, err := d.client.NewCompleteAggregateTransaction(d.deadline(), txs)
+	if err != nil {
+		return nil, err
+	}
+
+	signed, err := d.config.Owner.Sign(aggTx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := d.client.Transaction.announceAndWait(ctx, signed, d.config.PollInterval); err != nil {
+		return nil, err
+	}
+
+	for _, v := range deactivated {
+		v.Deactivated = true
+	}
+
+	if err := d.store.SaveSuperContractVersions(d.config.Drive.PublicKey, versions); err != nil {
+		return nil, err
+	}
+
+	return deactivated, nil
+}
+
+// storedFileSize returns size of file stored on drive
+func (d *SuperContractDeployer) storedFileSize(ctx context.Context, fileHash *Hash) (StorageSize, error) {
+	drive, err := d.client.Storage.GetDrive(ctx, d.config.Drive)
+	if err != nil {
+		return 0, err
+	}
+
+	size, ok := drive.Files[*fileHash]
+	if !ok {
+		return 0, ErrContractFileNotStored
+	}
+
+	return size, nil
+}
+
+func (d *SuperContractDeployer) deadline() *Deadline {
+	return NewDeadline(d.config.Deadline)
+}
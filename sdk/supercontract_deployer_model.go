@@ -0,0 +1,161 @@
+// Copyright 2020 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/proximax-storage/go-xpx-utils/str"
+)
+
+// SuperContractDeployerConfig is a configuration of SuperContractDeployer
+type SuperContractDeployerConfig struct {
+	// Owner is an owner of drive, it deploys and deactivates supercontracts
+	Owner *Account
+	Drive *PublicAccount
+	// Hasher computes hashes of contract files the same way as storage layer of drive
+	Hasher       DriveHasher
+	PollInterval time.Duration
+	Deadline     time.Duration
+}
+
+func (c *SuperContractDeployerConfig) validate() error {
+	if c == nil || c.Owner == nil || c.Drive == nil || c.Hasher == nil {
+		return ErrInvalidDeployerConfig
+	}
+
+	if c.PollInterval <= 0 {
+		c.PollInterval = time.Second
+	}
+
+	if c.Deadline <= 0 {
+		c.Deadline = time.Hour
+	}
+
+	return nil
+}
+
+// SuperContractVersion maps version of contract to supercontract deployed on drive
+type SuperContractVersion struct {
+	Version       uint64      `json:"version"`
+	SuperContract string      `json:"superContract"`
+	FileHash      *Hash       `json:"fileHash"`
+	FileSize      StorageSize `json:"fileSize"`
+	VMVersion     uint64      `json:"vmVersion"`
+	Deactivated   bool        `json:"deactivated"`
+}
+
+func (v *SuperContractVersion) String() string {
+	return str.StructToString(
+		"SuperContractVersion",
+		str.NewField("Version", str.IntPattern, v.Version),
+		str.NewField("SuperContract", str.StringPattern, v.SuperContract),
+		str.NewField("FileHash", str.StringPattern, v.FileHash),
+		str.NewField("FileSize", str.StringPattern, v.FileSize),
+		str.NewField("VMVersion", str.IntPattern, v.VMVersion),
+		str.NewField("Deactivated", str.BooleanPattern, v.Deactivated),
+	)
+}
+
+// SuperContractVersionStore persists versions of supercontracts deployed on drive
+type SuperContractVersionStore interface {
+	// LoadSuperContractVersions returns versions ordered by version number
+	LoadSuperContractVersions(driveKey string) ([]*SuperContractVersion, error)
+	SaveSuperContractVersions(driveKey string, versions []*SuperContractVersion) error
+}
+
+func sortSuperContractVersions(versions []*SuperContractVersion) {
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].Version < versions[j].Version
+	})
+}
+
+func copySuperContractVersions(versions []*SuperContractVersion) []*SuperContractVersion {
+	copied := make([]*SuperContractVersion, len(versions))
+	for i, v := range versions {
+		version := *v
+		copied[i] = &version
+	}
+
+	sortSuperContractVersions(copied)
+
+	return copied
+}
+
+type memorySuperContractVersionStore struct {
+	sync.Mutex
+	versions map[string][]*SuperContractVersion
+}
+
+// NewMemorySuperContractVersionStore returns store which keeps versions only while process is alive
+func NewMemorySuperContractVersionStore() SuperContractVersionStore {
+	return &memorySuperContractVersionStore{versions: make(map[string][]*SuperContractVersion)}
+}
+
+func (s *memorySuperContractVersionStore) LoadSuperContractVersions(driveKey string) ([]*SuperContractVersion, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	return copySuperContractVersions(s.versions[driveKey]), nil
+}
+
+func (s *memorySuperContractVersionStore) SaveSuperContractVersions(driveKey string, versions []*SuperContractVersion) error {
+	s.Lock()
+	defer s.Unlock()
+
+	s.versions[driveKey] = copySuperContractVersions(versions)
+	return nil
+}
+
+type fileSuperContractVersionStore struct {
+	dir string
+}
+
+// NewFileSuperContractVersionStore returns store which keeps versions of every drive in JSON file inside of dir
+func NewFileSuperContractVersionStore(dir string) (SuperContractVersionStore, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	return &fileSuperContractVersionStore{dir: dir}, nil
+}
+
+func (s *fileSuperContractVersionStore) path(driveKey string) string {
+	return filepath.Join(s.dir, driveKey+".versions.json")
+}
+
+func (s *fileSuperContractVersionStore) LoadSuperContractVersions(driveKey string) ([]*SuperContractVersion, error) {
+	data, err := ioutil.ReadFile(s.path(driveKey))
+	if os.IsNotExist(err) {
+		return []*SuperContractVersion{}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]*SuperContractVersion, 0)
+	if err := json.Unmarshal(data, &versions); err != nil {
+		return nil, err
+	}
+
+	sortSuperContractVersions(versions)
+
+	return versions, nil
+}
+
+func (s *fileSuperContractVersionStore) SaveSuperContractVersions(driveKey string, versions []*SuperContractVersion) error {
+	data, err := json.Marshal(copySuperContractVersions(versions))
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(s.path(driveKey), data)
+}
@@ -0,0 +1,110 @@
+package sdk
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuperContractDeployer(t *testing.T) {
+	mock := newSdkMock(0)
+	defer mock.Close()
+
+	var lock sync.Mutex
+	announced := 0
+
+	// both versions of contract are uploaded to drive
+	v1, v2 := testContentHash("contract v1"), testContentHash("contract v2")
+	driveJson := strings.Replace(testDriveInfoJson, `"files": [`, fmt.Sprintf(
+		`"files": [{"fileHash": "%s", "size": [11, 0]}, {"fileHash": "%s", "size": [11, 0]}, `, v1, v2), 1)
+
+	mock.AddHandler(fmt.Sprintf(driveRoute, testDriveAccount.PublicKey), func(resp http.ResponseWriter, req *http.Request) {
+		fmt.Fprint(resp, driveJson)
+	})
+	mock.AddHandler(transactionsRoute, func(resp http.ResponseWriter, req *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		announced++
+
+		resp.WriteHeader(http.StatusAccepted)
+		fmt.Fprint(resp, `{"message": "packet 9 was pushed to the network via /transaction"}`)
+	})
+	mock.AddHandler(strings.TrimSuffix(transactionStatusByIdRoute, "%s"), func(resp http.ResponseWriter, req *http.Request) {
+		hash := req.URL.Path[strings.LastIndex(req.URL.Path, "/")+1:]
+		fmt.Fprintf(resp, `{"group": "confirmed", "status": "Success", "hash": "%s", "deadline": [1, 0], "height": [1, 0]}`, hash)
+	})
+
+	client := mock.getPublicTestClientUnsafe()
+	client.config.GenerationHash = &Hash{}
+
+	dir, err := ioutil.TempDir("", "supercontract-versions")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	store, err := NewFileSuperContractVersionStore(dir)
+	assert.Nil(t, err)
+
+	owner, err := NewAccount(PublicTest, &Hash{})
+	assert.Nil(t, err)
+
+	_, err = NewSuperContractDeployer(client, &SuperContractDeployerConfig{Owner: owner}, store)
+	assert.Equal(t, ErrInvalidDeployerConfig, err)
+	_, err = NewSuperContractDeployer(client, &SuperContractDeployerConfig{Owner: owner, Drive: testDriveAccount}, store)
+	assert.Equal(t, ErrInvalidDeployerConfig, err)
+
+	deployer, err := NewSuperContractDeployer(client, &SuperContractDeployerConfig{
+		Owner:        owner,
+		Drive:        testDriveAccount,
+		Hasher:       LocalDriveHasher{},
+		PollInterval: time.Millisecond,
+	}, store)
+	assert.Nil(t, err)
+
+	_, err = deployer.Deploy(ctx, nil, 1)
+	assert.Equal(t, ErrEmptyContractFile, err)
+
+	_, err = deployer.Deploy(ctx, []byte("contract v3"), 1)
+	assert.Equal(t, ErrContractFileNotStored, err)
+	assert.Equal(t, 0, announced)
+
+	first, err := deployer.Deploy(ctx, []byte("contract v1"), 1)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1), first.Version)
+	assert.Equal(t, v1, *first.FileHash)
+	assert.Equal(t, StorageSize(11), first.FileSize)
+
+	second, err := deployer.Deploy(ctx, []byte("contract v2"), 1)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(2), second.Version)
+	assert.NotEqual(t, first.SuperContract, second.SuperContract)
+	// every deploy announces only deployment of stored file
+	assert.Equal(t, 2, announced)
+
+	deactivated, err := deployer.DeactivateOlderVersions(ctx, second.Version)
+	assert.Nil(t, err)
+	assert.Len(t, deactivated, 1)
+	assert.Equal(t, first.SuperContract, deactivated[0].SuperContract)
+	assert.Equal(t, 3, announced)
+
+	_, err = deployer.DeactivateOlderVersions(ctx, second.Version)
+	assert.Equal(t, ErrNoChanges, err)
+
+	// versions are read back from store
+	versions, err := deployer.Versions()
+	assert.Nil(t, err)
+	assert.Len(t, versions, 2)
+	assert.True(t, versions[0].Deactivated)
+	assert.False(t, versions[1].Deactivated)
+
+	latest, err := deployer.Latest()
+	assert.Nil(t, err)
+	assert.Equal(t, second.SuperContract, latest.SuperContract)
+}
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"github.com/pkg/errors"
 	"net/http"
+	"time"
 )
 
 type TransactionService struct {
@@ -64,6 +65,32 @@ func (txs *TransactionService) GetTransactionsByGroup(ctx context.Context, group
 	return tspDTO.toStruct(txs.client.GenerationHash())
 }
 
+// IterateTransactionsByGroup returns iterator over transactions of every page matching options,
+// call Close when iteration is stopped early
+func (txs *TransactionService) IterateTransactionsByGroup(ctx context.Context, group TransactionGroup, tpOpts *TransactionsPageOptions, itOpts *IteratorOptions) *TransactionIterator {
+	opts := TransactionsPageOptions{}
+	if tpOpts != nil {
+		opts = *tpOpts
+	}
+
+	return &TransactionIterator{it: newPageIterator(ctx, func(ctx context.Context, pageNumber uint64) ([]interface{}, Pagination, error) {
+		pageOpts := opts
+		pageOpts.PageNumber = pageNumber
+
+		txPage, err := txs.GetTransactionsByGroup(ctx, group, &pageOpts)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+
+		items := make([]interface{}, len(txPage.Transactions))
+		for i, tx := range txPage.Transactions {
+			items[i] = tx
+		}
+
+		return items, txPage.Pagination, nil
+	}, itOpts)}
+}
+
 // GetTransactionsByIds returns an array of Transaction's for passed array of transaction ids or hashes
 func (txs *TransactionService) GetTransactionsByIds(ctx context.Context, group TransactionGroup, ids []string, tpOpts *TransactionsPageOptions) ([]Transaction, error) {
 	var b bytes.Buffer
@@ -188,6 +215,36 @@ func (txs *TransactionService) announceTransaction(ctx context.Context, tx inter
 	return m.Message, nil
 }
 
+// waitForConfirmation polls status of transaction until it is confirmed.
+// Unknown transaction is awaited, because status appears only after node processes announced transaction
+func (txs *TransactionService) waitForConfirmation(ctx context.Context, hash *Hash, interval time.Duration) error {
+	return poll(ctx, interval, func() (bool, error) {
+		status, err := txs.GetTransactionStatus(ctx, hash.String())
+		if isNotFoundError(err) {
+			return false, nil
+		}
+
+		if err != nil {
+			return false, err
+		}
+
+		if status.Status != successTransactionStatus {
+			return false, errors.Wrapf(ErrTransactionFailed, "%s: %s", hash, status.Status)
+		}
+
+		return status.Group == Confirmed, nil
+	})
+}
+
+// announceAndWait announces transaction and waits for its confirmation
+func (txs *TransactionService) announceAndWait(ctx context.Context, tx *SignedTransaction, interval time.Duration) error {
+	if _, err := txs.Announce(ctx, tx); err != nil {
+		return err
+	}
+
+	return txs.waitForConfirmation(ctx, tx.Hash, interval)
+}
+
 // GetTransactionEffectiveFee gets a transaction's effective paid fee
 func (txs *TransactionService) GetTransactionEffectiveFee(ctx context.Context, transactionId string) (int, error) {
 	tx, err := txs.GetTransaction(ctx, Confirmed, transactionId)
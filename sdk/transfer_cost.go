@@ -0,0 +1,153 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"context"
+)
+
+// PreviewTransfer returns debit of sender by transfer per mosaic: transferred amounts, levies of transferred mosaics
+// and max fee of transfer in XPX. Actual fee can be lower than max fee, so preview is an upper bound of debit
+func (txs *TransactionService) PreviewTransfer(ctx context.Context, sender *PublicAccount, tx *TransferTransaction) (*TransferPreview, error) {
+	if sender == nil {
+		return nil, ErrNilAccount
+	}
+
+	if tx == nil {
+		return nil, ErrArgumentNotValid
+	}
+
+	p := &TransferPreview{
+		Transaction: tx,
+		Sender:      sender,
+		Costs:       make([]*TransferCost, 0, len(tx.Mosaics)+1),
+	}
+
+	costs := make(map[uint64]*TransferCost)
+	costOf := func(mosaicId *MosaicId) *TransferCost {
+		c, ok := costs[mosaicId.Id()]
+		if !ok {
+			c = &TransferCost{MosaicId: mosaicId}
+			costs[mosaicId.Id()] = c
+			p.Costs = append(p.Costs, c)
+		}
+
+		return c
+	}
+
+	for _, m := range tx.Mosaics {
+		mosaicId, err := txs.client.Resolve.ResolveMosaicId(ctx, m.AssetId)
+		if err != nil {
+			return nil, err
+		}
+
+		c := costOf(mosaicId)
+		if c.Amount, err = addAmount(c.Amount, m.Amount); err != nil {
+			return nil, err
+		}
+
+		levy, err := txs.client.Mosaic.GetMosaicLevy(ctx, mosaicId)
+		if err != nil && !isNotFoundError(err) {
+			return nil, err
+		}
+
+		levyAmount, err := levy.LevyOf(m.Amount)
+		if err != nil {
+			return nil, err
+		}
+
+		if levyAmount == 0 {
+			continue
+		}
+
+		// levy without mosaic is paid in transferred mosaic
+		levyCost := c
+		if levy.MosaicId != nil {
+			levyCost = costOf(levy.MosaicId)
+		}
+
+		if levyCost.Levy, err = addAmount(levyCost.Levy, levyAmount); err != nil {
+			return nil, err
+		}
+	}
+
+	if tx.MaxFee > 0 {
+		xpxId, err := txs.client.Resolve.ResolveMosaicId(ctx, XpxNamespaceId)
+		if err != nil {
+			return nil, err
+		}
+
+		costOf(xpxId).Fee = tx.MaxFee
+	}
+
+	for _, c := range p.Costs {
+		if _, err := c.checkedTotal(); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(p.Costs) == 0 {
+		return p, nil
+	}
+
+	if err := txs.fillTransferBalances(ctx, p, costs); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// fillTransferBalances sets balances of sender, divisibility and names of mosaics to costs of preview
+func (txs *TransactionService) fillTransferBalances(ctx context.Context, p *TransferPreview, costs map[uint64]*TransferCost) error {
+	info, err := txs.client.Account.GetAccountInfo(ctx, p.Sender.Address)
+	if err != nil && !isNotFoundError(err) {
+		return err
+	}
+
+	if info != nil {
+		for _, m := range info.Mosaics {
+			if c, ok := costs[m.AssetId.Id()]; ok {
+				c.Balance = m.Amount
+			}
+		}
+	}
+
+	mosaics := make([]*Mosaic, len(p.Costs))
+	for i, c := range p.Costs {
+		mosaics[i] = newMosaicPanic(c.MosaicId, c.Amount)
+	}
+
+	amounts, err := txs.client.Resolve.GetMosaicAmounts(ctx, mosaics...)
+	if err != nil {
+		return err
+	}
+
+	for i, a := range amounts {
+		p.Costs[i].Divisibility = a.Divisibility
+		p.Costs[i].Name = a.Name
+	}
+
+	return nil
+}
+
+// NewCheckedTransferTransaction returns transfer with preview of its cost.
+// When sender can't pay for transfer, only preview is returned with ErrInsufficientBalance
+func (c *Client) NewCheckedTransferTransaction(ctx context.Context, sender *PublicAccount, deadline *Deadline, recipient *Address, mosaics []*Mosaic, message Message) (*TransferTransaction, *TransferPreview, error) {
+	tx, err := c.NewTransferTransaction(deadline, recipient, mosaics, message)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	preview, err := c.Transaction.PreviewTransfer(ctx, sender, tx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !preview.Covered() {
+		return nil, preview, ErrInsufficientBalance
+	}
+
+	return tx, preview, nil
+}
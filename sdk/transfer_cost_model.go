@@ -0,0 +1,123 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"math/big"
+
+	"github.com/proximax-storage/go-xpx-utils/str"
+)
+
+// TransferCost is a debit of one mosaic from sender by transfer
+type TransferCost struct {
+	MosaicId *MosaicId
+	// Amount is transferred to recipient
+	Amount Amount
+	// Levy is charged by levies of transferred mosaics which are paid in this mosaic
+	Levy Amount
+	// Fee is a max fee of transfer, it is paid in XPX
+	Fee Amount
+	// Balance of mosaic on sender account
+	Balance      Amount
+	Divisibility uint8
+	Name         string
+}
+
+// Total returns amount debited from sender including levy and fee
+func (c *TransferCost) Total() Amount {
+	return c.Amount + c.Levy + c.Fee
+}
+
+func (c *TransferCost) checkedTotal() (Amount, error) {
+	total, err := addAmount(c.Amount, c.Levy)
+	if err != nil {
+		return 0, err
+	}
+
+	return addAmount(total, c.Fee)
+}
+
+// Covered returns true when balance of sender covers total
+func (c *TransferCost) Covered() bool {
+	return c.Balance >= c.Total()
+}
+
+func (c *TransferCost) String() string {
+	return str.StructToString(
+		"TransferCost",
+		str.NewField("MosaicId", str.StringPattern, c.MosaicId),
+		str.NewField("Amount", str.StringPattern, c.format(c.Amount)),
+		str.NewField("Levy", str.StringPattern, c.format(c.Levy)),
+		str.NewField("Fee", str.StringPattern, c.format(c.Fee)),
+		str.NewField("Total", str.StringPattern, c.format(c.Total())),
+		str.NewField("Balance", str.StringPattern, c.format(c.Balance)),
+	)
+}
+
+func (c *TransferCost) format(amount Amount) string {
+	return (&MosaicAmount{AssetId: c.MosaicId, Amount: amount, Divisibility: c.Divisibility, Name: c.Name}).String()
+}
+
+// TransferPreview is a debit of sender by transfer per mosaic
+type TransferPreview struct {
+	Transaction *TransferTransaction
+	Sender      *PublicAccount
+	// Costs are ordered by the first appearance of mosaic in transfer, fee mosaic is the last when it isn't transferred
+	Costs []*TransferCost
+}
+
+// Covered returns true when sender can pay every cost of transfer
+func (p *TransferPreview) Covered() bool {
+	for _, c := range p.Costs {
+		if !c.Covered() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Cost returns cost of mosaic or nil when transfer doesn't debit it
+func (p *TransferPreview) Cost(mosaicId *MosaicId) *TransferCost {
+	for _, c := range p.Costs {
+		if c.MosaicId.Id() == mosaicId.Id() {
+			return c
+		}
+	}
+
+	return nil
+}
+
+func (p *TransferPreview) String() string {
+	return str.StructToString(
+		"TransferPreview",
+		str.NewField("Sender", str.StringPattern, p.Sender),
+		str.NewField("Costs", str.StringPattern, p.Costs),
+	)
+}
+
+// LevyOf returns levy charged for transfer of amount, it is paid in MosaicId of levy
+func (levy *MosaicLevy) LevyOf(amount Amount) (Amount, error) {
+	if levy == nil {
+		return 0, nil
+	}
+
+	switch levy.Type {
+	case LevyAbsoluteFee:
+		return levy.Fee, nil
+	case LevyPercentileFee:
+		// percentile fee is a percent of amount multiplied by MosaicLevyDecimalPlace
+		l := new(big.Int).Mul(big.NewInt(int64(amount)), big.NewInt(int64(levy.Fee)))
+		l.Quo(l, big.NewInt(100*MosaicLevyDecimalPlace))
+
+		if !l.IsInt64() {
+			return 0, ErrMosaicAmountOverflow
+		}
+
+		return Amount(l.Int64()), nil
+	default:
+		return 0, nil
+	}
+}
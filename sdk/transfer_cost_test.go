@@ -0,0 +1,115 @@
+package sdk
+
+import (
+	"encoding/base32"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/proximax-storage/go-xpx-utils/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMosaicLevy_LevyOf(t *testing.T) {
+	levy := &MosaicLevy{Type: LevyPercentileFee, Fee: CreateMosaicLevyFeePercentile(1.5)}
+
+	amount, err := levy.LevyOf(1000000)
+	assert.Nil(t, err)
+	assert.Equal(t, Amount(15000), amount)
+
+	levy = &MosaicLevy{Type: LevyAbsoluteFee, Fee: 30}
+	amount, err = levy.LevyOf(1000000)
+	assert.Nil(t, err)
+	assert.Equal(t, Amount(30), amount)
+
+	var none *MosaicLevy
+	amount, err = none.LevyOf(1000000)
+	assert.Nil(t, err)
+	assert.Equal(t, Amount(0), amount)
+
+	levy = &MosaicLevy{Type: LevyPercentileFee, Fee: CreateMosaicLevyFeePercentile(200)}
+	_, err = levy.LevyOf(1 << 62)
+	assert.Equal(t, ErrMosaicAmountOverflow, err)
+}
+
+func TestTransactionService_PreviewTransfer(t *testing.T) {
+	mockServer := newSdkMock(0)
+	defer mockServer.Close()
+
+	sender, err := NewAccount(PublicTest, &Hash{})
+	assert.Nil(t, err)
+
+	xpxId := newMosaicIdPanic(uint64DTO{1382215848, 1583663204}.toUint64())
+
+	mockServer.AddRouter(&mock.Router{
+		Path: fmt.Sprintf(namespaceRoute, XpxNamespaceId.toHexString()),
+		RespBody: strings.Replace(testRenewalNamespaceJson(testMarketOwner, XpxNamespaceId, 0),
+			`"alias": {"type": 0}`, `"alias": {"type": 1, "mosaicId": [1382215848, 1583663204]}`, 1),
+	})
+	// levy of 1% of mosaic is paid in XPX
+	mockServer.AddRouter(&mock.Router{
+		Path: fmt.Sprintf(mosaicLevyRoute, testMosaicPathID),
+		RespBody: fmt.Sprintf(`{"type": 2, "recipient": "%s", "mosaicId": [1382215848, 1583663204], "fee": [100000, 0]}`,
+			testAddressHex(testMarketOwner.Address)),
+	})
+	mockServer.AddRouter(&mock.Router{
+		Path:     mosaicsRoute,
+		RespBody: "[" + testMosaicInfoJson + ", " + testSpecMosaicJson(testMarketOwner, xpxId, 1000000, Transferable) + "]",
+	})
+	mockServer.AddRouter(&mock.Router{
+		Path:     mosaicNamesRoute,
+		RespBody: `[{"mosaicId": [298950589, 1817567325], "names": ["token"]}, {"mosaicId": [1382215848, 1583663204], "names": ["prx.xpx"]}]`,
+	})
+	mockServer.AddRouter(&mock.Router{
+		Path: fmt.Sprintf(accountRoute, sender.PublicAccount.Address.Address),
+		RespBody: fmt.Sprintf(`{"meta": {}, "account": {
+			"address": "%s", "addressHeight": [1, 0], "publicKey": "%s", "publicKeyHeight": [1, 0], "accountType": 0,
+			"linkedAccountKey": "0000000000000000000000000000000000000000000000000000000000000000",
+			"mosaics": [{"id": [298950589, 1817567325], "amount": [2000000, 0]}, {"id": [1382215848, 1583663204], "amount": [10000, 0]}]
+		}}`, testAddressHex(sender.PublicAccount.Address), sender.PublicAccount.PublicKey),
+	})
+
+	client := mockServer.getPublicTestClientUnsafe()
+
+	tx, err := client.NewTransferTransaction(NewDeadline(time.Hour), testMarketOwner.Address,
+		[]*Mosaic{newMosaicPanic(mosaicCorr.MosaicId, 1000000), newMosaicPanic(XpxNamespaceId, 500)}, NewPlainMessage(""))
+	assert.Nil(t, err)
+	tx.MaxFee = 100
+
+	preview, err := client.Transaction.PreviewTransfer(ctx, sender.PublicAccount, tx)
+	assert.Nil(t, err)
+	assert.Len(t, preview.Costs, 2)
+
+	token := preview.Cost(mosaicCorr.MosaicId)
+	assert.Equal(t, Amount(1000000), token.Total())
+	assert.True(t, token.Covered())
+	assert.Equal(t, uint8(6), token.Divisibility)
+
+	xpx := preview.Cost(xpxId)
+	assert.Equal(t, Amount(500), xpx.Amount)
+	assert.Equal(t, Amount(10000), xpx.Levy)
+	assert.Equal(t, Amount(100), xpx.Fee)
+	assert.Equal(t, Amount(10600), xpx.Total())
+	assert.Equal(t, "106.00 prx.xpx", xpx.format(xpx.Total()))
+	assert.False(t, preview.Covered())
+
+	_, preview, err = client.NewCheckedTransferTransaction(ctx, sender.PublicAccount, NewDeadline(time.Hour), testMarketOwner.Address,
+		[]*Mosaic{newMosaicPanic(mosaicCorr.MosaicId, 1000000)}, NewPlainMessage(""))
+	assert.Equal(t, ErrInsufficientBalance, err)
+	assert.NotNil(t, preview)
+
+	// levy of smaller transfer is covered when transfer has no fee
+	client.config.FeeCalculationStrategy = 0
+	checked, _, err := client.NewCheckedTransferTransaction(ctx, sender.PublicAccount, NewDeadline(time.Hour), testMarketOwner.Address,
+		[]*Mosaic{newMosaicPanic(mosaicCorr.MosaicId, 100000)}, NewPlainMessage(""))
+	assert.Nil(t, err)
+	assert.NotNil(t, checked)
+}
+
+func testAddressHex(address *Address) string {
+	raw, _ := base32.StdEncoding.DecodeString(address.Address)
+
+	return strings.ToUpper(hex.EncodeToString(raw))
+}
@@ -1,6 +1,12 @@
 package sdk
 
-import "encoding/hex"
+import (
+	"context"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"time"
+)
 
 func bytesToHash(bytes []byte) (*Hash, error) {
 	if len(bytes) != 32 {
@@ -67,3 +73,41 @@ func stringToSignaturePanic(signature string) *Signature {
 
 	return arr
 }
+
+// isNotFoundError returns true when REST responded that requested resource doesn't exist
+func isNotFoundError(err error) bool {
+	if e, ok := err.(*HttpError); ok {
+		return e.StatusCode == 404
+	}
+
+	return err == ErrResourceNotFound
+}
+
+// poll calls fn every interval until it is done, returns error or context is done
+func poll(ctx context.Context, interval time.Duration, fn func() (bool, error)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		done, err := fn()
+		if err != nil || done {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// writeFileAtomic writes data to temporary file and renames it, so crash doesn't leave partially written file
+func writeFileAtomic(path string, data []byte) error {
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
@@ -34,6 +34,7 @@ const (
 
 var (
 	ErrUnsupportedMessageType = errors.New("unsupported message type")
+	ErrNilDriveFetcher        = errors.New("drive and drive fetcher should not be nil")
 )
 
 type (
@@ -59,6 +60,8 @@ type (
 		unconfirmedAddedSubscribers   subscribers.UnconfirmedAdded
 		unconfirmedRemovedSubscribers subscribers.UnconfirmedRemoved
 
+		driveSnapshots *driveSnapshots
+
 		messageRouter    Router
 		topicHandlers    TopicHandlersStorage
 		messagePublisher MessagePublisher
@@ -90,6 +93,7 @@ type (
 		AddStatusHandlers(address *sdk.Address, handlers ...subscribers.StatusHandler) error
 		AddCosignatureHandlers(address *sdk.Address, handlers ...subscribers.CosignatureHandler) error
 		AddDriveStateHandlers(address *sdk.Address, handlers ...subscribers.DriveStateHandler) error
+		AddDriveDiffHandlers(drive *sdk.PublicAccount, fetcher DriveFetcher, handlers ...subscribers.DriveDiffHandler) error
 	}
 )
 
@@ -112,6 +116,8 @@ func NewClient(ctx context.Context, cfg *sdk.Config) (CatapultClient, error) {
 		unconfirmedAddedSubscribers:   subscribers.NewUnconfirmedAdded(),
 		unconfirmedRemovedSubscribers: subscribers.NewUnconfirmedRemoved(),
 
+		driveSnapshots: newDriveSnapshots(),
+
 		topicHandlers: &topicHandlers{h: make(topicHandlersMap)},
 
 		listenCh:     make(chan bool),
@@ -380,6 +386,27 @@ func (c *CatapultWebsocketClientImpl) AddDriveStateHandlers(address *sdk.Address
 	return nil
 }
 
+// AddDriveDiffHandlers subscribes to changes of drive state. On every change drive is fetched and
+// compared with the previous snapshot, so handlers receive joined and left replicators, files and billing entries.
+// Drive of DriveDiff is nil when fetching of drive failed, the previous snapshot is kept for the next change
+func (c *CatapultWebsocketClientImpl) AddDriveDiffHandlers(drive *sdk.PublicAccount, fetcher DriveFetcher, handlers ...subscribers.DriveDiffHandler) error {
+	if len(handlers) == 0 {
+		return nil
+	}
+
+	if drive == nil || fetcher == nil {
+		return ErrNilDriveFetcher
+	}
+
+	if !c.driveSnapshots.has(drive.PublicKey) {
+		if d, err := fetcher.GetDrive(c.ctx, drive); err == nil {
+			c.driveSnapshots.swap(drive.PublicKey, d)
+		}
+	}
+
+	return c.AddDriveStateHandlers(drive.Address, c.driveSnapshots.handler(c.ctx, drive, fetcher, handlers))
+}
+
 func (c *CatapultWebsocketClientImpl) handleSignal() {
 	for {
 		select {
@@ -432,6 +459,7 @@ func (c *CatapultWebsocketClientImpl) removeHandlers() {
 	c.statusSubscribers = nil
 	c.cosignatureSubscribers = nil
 	c.driveStateSubscribers = nil
+	c.driveSnapshots = nil
 
 	c.topicHandlers = nil
 }
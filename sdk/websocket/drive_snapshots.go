@@ -0,0 +1,91 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package websocket
+
+import (
+	"context"
+	"sync"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk/websocket/subscribers"
+)
+
+// DriveFetcher returns drive by its key, it's implemented by sdk.StorageService
+type DriveFetcher interface {
+	GetDrive(ctx context.Context, driveKey *sdk.PublicAccount) (*sdk.Drive, error)
+}
+
+// driveSnapshots caches the last fetched snapshot of every drive to seed new subscriptions
+type driveSnapshots struct {
+	sync.Mutex
+	drives map[string]*sdk.Drive
+}
+
+func newDriveSnapshots() *driveSnapshots {
+	return &driveSnapshots{drives: make(map[string]*sdk.Drive)}
+}
+
+func (s *driveSnapshots) has(driveKey string) bool {
+	s.Lock()
+	defer s.Unlock()
+
+	_, ok := s.drives[driveKey]
+	return ok
+}
+
+// swap stores snapshot of drive and returns the previous one
+func (s *driveSnapshots) swap(driveKey string, drive *sdk.Drive) *sdk.Drive {
+	s.Lock()
+	defer s.Unlock()
+
+	previous := s.drives[driveKey]
+	s.drives[driveKey] = drive
+
+	return previous
+}
+
+func (s *driveSnapshots) get(driveKey string) *sdk.Drive {
+	s.Lock()
+	defer s.Unlock()
+
+	return s.drives[driveKey]
+}
+
+// handler returns drive state handler which delivers difference of snapshots to handlers,
+// it's removed when every handler asks for removal.
+// Every subscription keeps its own previous snapshot seeded from the cache, so subscriptions
+// on the same drive don't consume changes of each other
+func (s *driveSnapshots) handler(ctx context.Context, drive *sdk.PublicAccount, fetcher DriveFetcher, handlers []subscribers.DriveDiffHandler) subscribers.DriveStateHandler {
+	var lock sync.Mutex
+	remaining := append([]subscribers.DriveDiffHandler{}, handlers...)
+	previous := s.get(drive.PublicKey)
+
+	return func(info *sdk.DriveStateInfo) bool {
+		lock.Lock()
+		defer lock.Unlock()
+
+		var diff *sdk.DriveDiff
+		if current, err := fetcher.GetDrive(ctx, drive); err == nil {
+			diff = sdk.DiffDrives(previous, current)
+			previous = current
+			s.swap(drive.PublicKey, current)
+		} else {
+			diff = sdk.DiffDrives(previous, nil)
+		}
+
+		diff.DriveKey = info.DriveKey
+		diff.State = info.State
+
+		left := remaining[:0]
+		for _, h := range remaining {
+			if !h(diff) {
+				left = append(left, h)
+			}
+		}
+		remaining = left
+
+		return len(remaining) == 0
+	}
+}
@@ -0,0 +1,136 @@
+package websocket
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk/websocket/subscribers"
+)
+
+type testDriveFetcher struct {
+	drives []*sdk.Drive
+	err    error
+}
+
+func (f *testDriveFetcher) GetDrive(ctx context.Context, driveKey *sdk.PublicAccount) (*sdk.Drive, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	drive := f.drives[0]
+	if len(f.drives) > 1 {
+		f.drives = f.drives[1:]
+	}
+
+	return drive, nil
+}
+
+func TestDriveSnapshots_Handler(t *testing.T) {
+	driveAccount, err := sdk.NewAccountFromPublicKey("415C7C61822B063F62A4876A6F6BA2DAAE114AB298D7AC7FC56FDBA95872C309", sdk.PublicTest)
+	assert.Nil(t, err)
+
+	fetcher := &testDriveFetcher{drives: []*sdk.Drive{
+		{DriveAccount: driveAccount, State: sdk.InProgress, Files: map[sdk.Hash]sdk.StorageSize{{1}: 10}},
+		{DriveAccount: driveAccount, State: sdk.Finished},
+	}}
+
+	snapshots := newDriveSnapshots()
+	snapshots.swap(driveAccount.PublicKey, &sdk.Drive{DriveAccount: driveAccount, State: sdk.Pending})
+
+	diffs := make([]*sdk.DriveDiff, 0)
+	handler := snapshots.handler(context.Background(), driveAccount, fetcher, []subscribers.DriveDiffHandler{
+		func(diff *sdk.DriveDiff) bool {
+			diffs = append(diffs, diff)
+			return diff.State == sdk.Finished
+		},
+	})
+
+	assert.False(t, handler(&sdk.DriveStateInfo{DriveKey: driveAccount.PublicKey, State: sdk.InProgress}))
+	assert.Equal(t, sdk.Pending, diffs[0].PreviousState)
+	assert.Equal(t, sdk.InProgress, diffs[0].State)
+	assert.Len(t, diffs[0].AddedFiles, 1)
+
+	// previous snapshot is kept when drive can't be fetched
+	fetcher.err = errors.New("node is unavailable")
+	assert.False(t, handler(&sdk.DriveStateInfo{DriveKey: driveAccount.PublicKey, State: sdk.InProgress}))
+	assert.Nil(t, diffs[1].Drive)
+	assert.Empty(t, diffs[1].AddedFiles)
+
+	fetcher.err = nil
+	assert.True(t, handler(&sdk.DriveStateInfo{DriveKey: driveAccount.PublicKey, State: sdk.Finished}))
+	assert.Equal(t, sdk.InProgress, diffs[2].PreviousState)
+	assert.Len(t, diffs[2].RemovedFiles, 1)
+}
+
+func TestDriveSnapshots_HandlerPerSubscription(t *testing.T) {
+	driveAccount, err := sdk.NewAccountFromPublicKey("415C7C61822B063F62A4876A6F6BA2DAAE114AB298D7AC7FC56FDBA95872C309", sdk.PublicTest)
+	assert.Nil(t, err)
+
+	fetcher := &testDriveFetcher{drives: []*sdk.Drive{
+		{DriveAccount: driveAccount, State: sdk.InProgress, Files: map[sdk.Hash]sdk.StorageSize{{1}: 10}},
+	}}
+
+	snapshots := newDriveSnapshots()
+	snapshots.swap(driveAccount.PublicKey, &sdk.Drive{DriveAccount: driveAccount, State: sdk.Pending})
+
+	diffs := make([]*sdk.DriveDiff, 0)
+	collect := []subscribers.DriveDiffHandler{
+		func(diff *sdk.DriveDiff) bool {
+			diffs = append(diffs, diff)
+			return false
+		},
+	}
+	first := snapshots.handler(context.Background(), driveAccount, fetcher, collect)
+	second := snapshots.handler(context.Background(), driveAccount, fetcher, collect)
+
+	// both subscriptions receive the same change of drive
+	info := &sdk.DriveStateInfo{DriveKey: driveAccount.PublicKey, State: sdk.InProgress}
+	assert.False(t, first(info))
+	assert.False(t, second(info))
+	assert.Len(t, diffs, 2)
+	for _, diff := range diffs {
+		assert.Equal(t, sdk.Pending, diff.PreviousState)
+		assert.Len(t, diff.AddedFiles, 1)
+	}
+
+	// new subscription starts from the latest snapshot
+	assert.Equal(t, sdk.InProgress, snapshots.get(driveAccount.PublicKey).State)
+}
+
+func TestCatapultWebsocketClientImpl_AddDriveDiffHandlers(t *testing.T) {
+	uid := "123456"
+	driveAccount, err := sdk.NewAccountFromPublicKey("415C7C61822B063F62A4876A6F6BA2DAAE114AB298D7AC7FC56FDBA95872C309", sdk.PublicTest)
+	assert.Nil(t, err)
+
+	mockMessagePublisher := new(MockMessagePublisher)
+	mockMessagePublisher.On("PublishSubscribeMessage", uid, mock.Anything).Return(nil)
+
+	mockTopicHandler := new(MockTopicHandlersStorage)
+	mockTopicHandler.On("HasHandler", mock.Anything).Return(true)
+
+	c := &CatapultWebsocketClientImpl{
+		ctx:                   context.Background(),
+		config:                &sdk.Config{},
+		UID:                   uid,
+		driveStateSubscribers: subscribers.NewDriveState(),
+		driveSnapshots:        newDriveSnapshots(),
+		topicHandlers:         mockTopicHandler,
+		messagePublisher:      mockMessagePublisher,
+	}
+
+	handler := func(*sdk.DriveDiff) bool { return false }
+	fetcher := &testDriveFetcher{drives: []*sdk.Drive{{DriveAccount: driveAccount, State: sdk.Pending}}}
+
+	assert.Nil(t, c.AddDriveDiffHandlers(driveAccount, fetcher))
+	assert.Equal(t, ErrNilDriveFetcher, c.AddDriveDiffHandlers(driveAccount, nil, handler))
+
+	assert.Nil(t, c.AddDriveDiffHandlers(driveAccount, fetcher, handler))
+	assert.True(t, c.driveStateSubscribers.HasHandlers(driveAccount.Address))
+	assert.Equal(t, sdk.Pending, c.driveSnapshots.get(driveAccount.PublicKey).State)
+	mockMessagePublisher.AssertNumberOfCalls(t, "PublishSubscribeMessage", 1)
+}
@@ -0,0 +1,38 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package websocket
+
+import (
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+)
+
+// WatchNamespaceCache keeps cache in line with chain: every new block moves height of cache, so aliases of
+// expired namespaces are dropped, and confirmed alias transactions of owners invalidate aliases they change.
+// Alias transactions are signed by owners of namespaces, so owners should include every account which aliases cached namespaces
+func WatchNamespaceCache(client CatapultClient, cache *sdk.NamespaceCache, owners ...*sdk.Address) error {
+	if cache == nil {
+		return nil
+	}
+
+	err := client.AddBlockHandlers(func(block *sdk.BlockInfo) bool {
+		cache.HandleBlock(block)
+		return false
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, owner := range owners {
+		err := client.AddConfirmedAddedHandlers(owner, func(tx sdk.Transaction) bool {
+			cache.HandleTransaction(tx)
+			return false
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
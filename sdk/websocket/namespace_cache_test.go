@@ -0,0 +1,62 @@
+package websocket
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk/websocket/subscribers"
+)
+
+// testCacheClient records handlers of namespace cache
+type testCacheClient struct {
+	CatapultClient
+	blockHandlers     []subscribers.BlockHandler
+	confirmedHandlers map[string][]subscribers.ConfirmedAddedHandler
+}
+
+func (c *testCacheClient) AddBlockHandlers(handlers ...subscribers.BlockHandler) error {
+	c.blockHandlers = append(c.blockHandlers, handlers...)
+	return nil
+}
+
+func (c *testCacheClient) AddConfirmedAddedHandlers(address *sdk.Address, handlers ...subscribers.ConfirmedAddedHandler) error {
+	c.confirmedHandlers[address.Address] = append(c.confirmedHandlers[address.Address], handlers...)
+	return nil
+}
+
+func TestWatchNamespaceCache(t *testing.T) {
+	owner, err := sdk.NewAccountFromPublicKey("415C7C61822B063F62A4876A6F6BA2DAAE114AB298D7AC7FC56FDBA95872C309", sdk.PublicTest)
+	assert.Nil(t, err)
+
+	namespaceId, err := sdk.NewNamespaceIdFromName("prx.xpx")
+	assert.Nil(t, err)
+
+	mosaicId, err := sdk.NewMosaicId(0x26514E2A1EF33824)
+	assert.Nil(t, err)
+
+	client := &testCacheClient{confirmedHandlers: make(map[string][]subscribers.ConfirmedAddedHandler)}
+	cache := sdk.NewNamespaceCache(0)
+
+	assert.Nil(t, WatchNamespaceCache(client, cache, owner.Address))
+	assert.Len(t, client.blockHandlers, 1)
+	assert.Len(t, client.confirmedHandlers[owner.Address.Address], 1)
+
+	aliasTx, err := sdk.NewMosaicAliasTransaction(sdk.NewDeadline(time.Hour), mosaicId, namespaceId, sdk.AliasLink, sdk.PublicTest)
+	assert.Nil(t, err)
+
+	info := &sdk.NamespaceInfo{NamespaceId: namespaceId, Active: true, Alias: &sdk.NamespaceAlias{Type: sdk.MosaicAliasType}, EndHeight: 10}
+
+	// handlers are kept after every event
+	cache.Put(info)
+	assert.False(t, client.blockHandlers[0](&sdk.BlockInfo{Height: 10}))
+	assert.Nil(t, cache.Alias(namespaceId))
+
+	info.EndHeight = 100
+	cache.Put(info)
+	assert.NotNil(t, cache.Alias(namespaceId))
+	assert.False(t, client.confirmedHandlers[owner.Address.Address][0](aliasTx))
+	assert.Nil(t, cache.Alias(namespaceId))
+}
@@ -9,6 +9,9 @@ import (
 type (
 	DriveStateHandler func(*sdk.DriveStateInfo) bool
 
+	// DriveDiffHandler receives difference between snapshots of drive on every change of its state
+	DriveDiffHandler func(*sdk.DriveDiff) bool
+
 	DriveState interface {
 		AddHandlers(address *sdk.Address, handlers ...DriveStateHandler) error
 		RemoveHandlers(address *sdk.Address, handlers ...*DriveStateHandler) bool
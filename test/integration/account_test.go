@@ -5,7 +5,9 @@
 package integration
 
 import (
+	"crypto/rand"
 	"testing"
+	"time"
 
 	"github.com/proximax-storage/go-xpx-utils/tests"
 	"github.com/stretchr/testify/assert"
@@ -40,3 +42,168 @@ func TestAddressService_GetAccountNames(t *testing.T) {
 		tests.ValidateStringers(t, addresses[i], accNames.Address)
 	}
 }
+
+// withMaxFee sets max fee of created transaction, so balances are replayed with fees
+func withMaxFee(create CreateTransaction) CreateTransaction {
+	return func() (sdk.Transaction, error) {
+		tx, err := create()
+		if err != nil {
+			return nil, err
+		}
+
+		tx.GetAbstractTransaction().MaxFee = sdk.Amount(1000)
+		return tx, nil
+	}
+}
+
+func TestAccountService_GetBalanceAtHeight_MatchesAccountInfo(t *testing.T) {
+	account, err := client.NewAccount()
+	assert.Nil(t, err)
+	driveAccount, err := client.NewAccount()
+	assert.Nil(t, err)
+
+	var driveSize, fileSize uint64 = 1000, 100
+
+	// account receives mosaics and becomes replicator of new drive
+	driveTx, err := client.NewPrepareDriveTransaction(
+		sdk.NewDeadline(time.Hour),
+		defaultAccount.PublicAccount,
+		sdk.Duration(10),
+		sdk.Duration(10),
+		sdk.Amount(50),
+		sdk.StorageSize(driveSize),
+		1,
+		1,
+		1,
+	)
+	assert.Nil(t, err)
+	driveTx.ToAggregate(driveAccount.PublicAccount)
+
+	fundTx, err := client.NewTransferTransaction(
+		sdk.NewDeadline(time.Hour),
+		account.Address,
+		[]*sdk.Mosaic{sdk.XpxRelative(1000), sdk.Storage(10000), sdk.Streaming(1000)},
+		sdk.NewPlainMessage("history"),
+	)
+	assert.Nil(t, err)
+	fundTx.ToAggregate(defaultAccount.PublicAccount)
+
+	result := sendTransaction(t, func() (sdk.Transaction, error) {
+		return client.NewCompleteAggregateTransaction(sdk.NewDeadline(time.Hour), []sdk.Transaction{driveTx, fundTx})
+	}, defaultAccount, driveAccount)
+	assert.Nil(t, result.error)
+
+	result = sendTransaction(t, withMaxFee(func() (sdk.Transaction, error) {
+		return client.NewTransferTransaction(
+			sdk.NewDeadline(time.Hour),
+			defaultAccount.Address,
+			[]*sdk.Mosaic{sdk.XpxRelative(100)},
+			sdk.NewPlainMessage("history"),
+		)
+	}), account)
+	assert.Nil(t, result.error)
+
+	// hash lock stays locked since aggregate is never announced
+	lockHash := &sdk.Hash{}
+	_, err = rand.Read(lockHash[:])
+	assert.Nil(t, err)
+
+	result = sendTransaction(t, withMaxFee(func() (sdk.Transaction, error) {
+		return client.NewLockFundsTransaction(
+			sdk.NewDeadline(time.Hour),
+			sdk.XpxRelative(10),
+			sdk.Duration(100),
+			&sdk.SignedTransaction{EntityType: sdk.AggregateBonded, Hash: lockHash},
+		)
+	}), account)
+	assert.Nil(t, result.error)
+
+	proofB := make([]byte, 8)
+	_, err = rand.Read(proofB)
+	assert.Nil(t, err)
+
+	proof := sdk.NewProofFromBytes(proofB)
+	secret, err := proof.Secret(sdk.SHA3_256)
+	assert.Nil(t, err)
+
+	result = sendTransaction(t, withMaxFee(func() (sdk.Transaction, error) {
+		return client.NewSecretLockTransaction(
+			sdk.NewDeadline(time.Hour),
+			sdk.XpxRelative(20),
+			sdk.Duration(100),
+			secret,
+			defaultAccount.Address,
+		)
+	}), account)
+	assert.Nil(t, result.error)
+
+	result = sendTransaction(t, withMaxFee(func() (sdk.Transaction, error) {
+		return client.NewSecretProofTransaction(sdk.NewDeadline(time.Hour), sdk.SHA3_256, proof, defaultAccount.Address)
+	}), account)
+	assert.Nil(t, result.error)
+
+	// sell offer is partially confirmed by counterparty
+	result = sendTransaction(t, withMaxFee(func() (sdk.Transaction, error) {
+		return client.NewAddExchangeOfferTransaction(
+			sdk.NewDeadline(time.Hour),
+			[]*sdk.AddOffer{{Offer: sdk.Offer{Type: sdk.SellOffer, Mosaic: sdk.Storage(100), Cost: sdk.Amount(10)}, Duration: sdk.Duration(100)}},
+		)
+	}), account)
+	assert.Nil(t, result.error)
+
+	result = sendTransaction(t, func() (sdk.Transaction, error) {
+		return client.NewExchangeOfferTransaction(
+			sdk.NewDeadline(time.Hour),
+			[]*sdk.ExchangeConfirmation{{
+				Offer: sdk.Offer{Type: sdk.SellOffer, Mosaic: sdk.Storage(50), Cost: sdk.Amount(5)},
+				Owner: account.PublicAccount,
+			}},
+		)
+	}, defaultAccount)
+	assert.Nil(t, result.error)
+
+	// storage deposits of replicator
+	result = sendTransaction(t, withMaxFee(func() (sdk.Transaction, error) {
+		return client.NewJoinToDriveTransaction(sdk.NewDeadline(time.Hour), driveAccount.PublicAccount)
+	}), account)
+	assert.Nil(t, result.error)
+
+	fileHash := &sdk.Hash{}
+	_, err = rand.Read(fileHash[:])
+	assert.Nil(t, err)
+
+	result = sendTransaction(t, func() (sdk.Transaction, error) {
+		return client.NewDriveFileSystemTransaction(
+			sdk.NewDeadline(time.Hour),
+			driveAccount.PublicAccount.PublicKey,
+			&sdk.Hash{1},
+			&sdk.Hash{},
+			[]*sdk.Action{{FileHash: fileHash, FileSize: sdk.StorageSize(fileSize)}},
+			[]*sdk.Action{},
+		)
+	}, defaultAccount)
+	assert.Nil(t, result.error)
+
+	result = sendTransaction(t, withMaxFee(func() (sdk.Transaction, error) {
+		return client.NewFilesDepositTransaction(sdk.NewDeadline(time.Hour), driveAccount.PublicAccount, []*sdk.File{{FileHash: fileHash}})
+	}), account)
+	assert.Nil(t, result.error)
+
+	// balances replayed from history should match balances stored by node
+	height, err := client.Blockchain.GetBlockchainHeight(ctx)
+	assert.Nil(t, err)
+
+	info, err := client.Account.GetAccountInfo(ctx, account.Address)
+	assert.Nil(t, err)
+	assert.Len(t, info.Mosaics, 3)
+
+	for _, m := range info.Mosaics {
+		balance, err := client.Account.GetBalanceAtHeight(ctx, account.Address, m.AssetId, height)
+		assert.Nil(t, err)
+		assert.Equal(t, m.Amount, balance, m.AssetId.String())
+	}
+
+	history, err := client.Account.GetAccountHistory(ctx, account.Address, nil)
+	assert.Nil(t, err)
+	assert.Len(t, history.Balances, len(info.Mosaics))
+}